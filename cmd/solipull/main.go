@@ -8,13 +8,21 @@ import (
 	"github.com/MikkelvtK/solipull/internal/models"
 	"log/slog"
 	"os"
+	"os/signal"
 )
 
 func main() {
-	a := app.NewApplication()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 
-	cmd := cli.New(a.Serv, &models.AppMetrics{}, slog.Default())
-	if err := cmd.Run(context.Background(), os.Args); err != nil {
+	a, err := app.NewApplication()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error starting solipull: %v\n", err)
+		os.Exit(1)
+	}
+
+	cmd := cli.New(a.Serv, a.Creators, a.Collection, a.Database, a.Defaults, &models.AppMetrics{}, slog.Default())
+	if err := cmd.Run(ctx, os.Args); err != nil {
 		fmt.Fprintf(os.Stderr, "Error running cli: %v\n", err.Error())
 		os.Exit(1)
 	}
@@ -1,51 +1,208 @@
 package app
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/config"
 	"github.com/MikkelvtK/solipull/internal/database"
 	"github.com/MikkelvtK/solipull/internal/database/sqlite"
 	"github.com/MikkelvtK/solipull/internal/models"
 	"github.com/MikkelvtK/solipull/internal/scraper"
 	"github.com/MikkelvtK/solipull/internal/service"
 	"github.com/gocolly/colly/v2/queue"
-	"log"
 	"log/slog"
 	"os"
 )
 
 type Application struct {
-	Serv *service.SolicitationService
-	repo models.ComicBookRepository
+	Serv       *service.SolicitationService
+	Creators   *service.CreatorService
+	Collection *service.CollectionService
+	Database   *service.DatabaseService
+	// Defaults are the publishers and months to sync when none are given without a terminal.
+	Defaults models.SyncDefaults
+	repo     models.ComicBookRepository
 }
 
-func NewApplication() *Application {
+// NewApplication loads the config and opens the database. The database is migrated by DatabaseService.Prepare when a
+// command first needs the data, the sources are registered after that as they keep their crawl queue in it. So the
+// db commands can inspect, migrate or restore a database as it is.
+func NewApplication() (*Application, error) {
 	cfgDir, _ := os.UserConfigDir()
 
-	db := database.MustOpen(cfgDir+"/solipull/solipull.db", "sqlite")
+	cfg, err := config.Load(cfgDir + "/solipull/config.json")
+	if err != nil {
+		return nil, err
+	}
+
+	dbPath := cfgDir + "/solipull/solipull.db"
+	db, err := database.Open(dbPath, "sqlite")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database %s: %v", dbPath, err)
+	}
+
 	repo := sqlite.NewComicBookRepository(db)
+	people := sqlite.NewPersonRepository(db)
+	follows := sqlite.NewFollowRepository(db)
+	collection := sqlite.NewCollectionRepository(db)
+	series := sqlite.NewSeriesRepository(db)
+
+	a := &Application{
+		Creators:   service.NewCreatorService(people, follows),
+		Collection: service.NewCollectionService(collection, series),
+		Database: service.NewDatabaseService(database.NewMaintenance(db, dbPath), repo, people, follows, collection,
+			series, sqlite.NewDumpRepository(db), cfg.Retention),
+		Defaults: cfg.Sync,
+		repo:     repo,
+	}
+
+	providers, err := service.NewProviderRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	a.Database.OnPrepared(func(context.Context) error {
+		return registerProviders(providers, db, cfg)
+	})
+
+	a.Serv = service.NewSolicitationService(providers, repo, people, sqlite.NewSyncRunRepository(db))
 
-	e := scraper.NewComicReleasesExtractor(slog.Default())
-	q, err := queue.New(5, &queue.InMemoryQueueStorage{MaxSize: 10_000})
+	return a, nil
+}
+
+// registerProviders registers Comic Releases followed by the publisher-direct sources from the config.
+func registerProviders(providers *service.ProviderRegistry, db *sql.DB, cfg *config.Config) error {
+	cr, err := newComicReleasesProvider(db, cfg)
 	if err != nil {
-		log.Fatal(err)
+		return err
+	}
+
+	if err := providers.Register(cr); err != nil {
+		return err
 	}
 
-	navCollector, _ := scraper.NewCollector(service.Domain, 5)
-	solCollector, _ := scraper.NewCollector(service.Domain, 5)
+	for _, src := range cfg.Sources {
+		p, err := newPublisherProvider(db, cfg, src)
+		if err != nil {
+			return err
+		}
+
+		if err := providers.Register(p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newComicReleasesProvider(db *sql.DB, c *config.Config) (service.DataProvider, error) {
+	def := extractorDefinition(c, scraper.ComicReleasesSource)
+	ex, err := scraper.NewExtractor(def, slog.Default())
+	if err != nil {
+		return nil, fmt.Errorf("invalid extractor for %s: %v", scraper.ComicReleasesSource, err)
+	}
+
+	store := sqlite.NewQueueStorage(db, scraper.ComicReleasesSource)
+	q, err := queue.New(5, store)
+	if err != nil {
+		return nil, err
+	}
+
+	navCollector, err := scraper.NewCollector(scraper.ComicReleasesDomain, 5)
+	if err != nil {
+		return nil, err
+	}
+
+	solCollector, err := scraper.NewCollector(scraper.ComicReleasesDomain, 5)
+	if err != nil {
+		return nil, err
+	}
 
 	cfg := scraper.SConfig{
+		Name:     scraper.ComicReleasesSource,
+		StartURL: scraper.ComicReleasesStartURL,
+		Nav:      navCollector,
+		Sol:      solCollector,
+		Q:        q,
+		Store:    store,
+		Ex:       ex,
+		Layout:   def.Layout,
+		Logger:   slog.Default(),
+	}
+
+	return scraper.NewComicReleasesScraper(&cfg)
+}
+
+func newPublisherProvider(db *sql.DB, c *config.Config, src config.Source) (service.DataProvider, error) {
+	ex, err := scraper.NewExtractor(extractorDefinition(c, src.Name), slog.Default())
+	if err != nil {
+		return nil, fmt.Errorf("invalid extractor for %s: %v", src.Name, err)
+	}
+
+	store := sqlite.NewQueueStorage(db, src.Name)
+	q, err := queue.New(5, store)
+	if err != nil {
+		return nil, err
+	}
+
+	navCollector, err := scraper.NewCollector(src.Domain, 5)
+	if err != nil {
+		return nil, err
+	}
+
+	solCollector, err := scraper.NewCollector(src.Domain, 5)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := scraper.PConfig{
+		Name:      src.Name,
+		Publisher: src.Publisher,
+		StartURL:  src.StartURL,
+		Links:     src.Links,
+		Selectors: scraper.PublisherSelectors{
+			Item:        src.Selectors.Item,
+			Title:       src.Selectors.Title,
+			Details:     src.Selectors.Details,
+			ReleaseDate: src.Selectors.ReleaseDate,
+			Description: src.Selectors.Description,
+			Format:      src.Selectors.Format,
+		},
 		Nav:    navCollector,
 		Sol:    solCollector,
 		Q:      q,
-		Ex:     e,
+		Store:  store,
+		Ex:     ex,
 		Logger: slog.Default(),
 	}
 
-	s, _ := scraper.NewComicReleasesScraper(&cfg)
+	return scraper.NewPublisherScraper(&cfg)
+}
 
-	serv := service.NewSolicitationService(s, repo)
+// extractorDefinition returns the built-in extractor definition with the overrides from the config applied.
+func extractorDefinition(c *config.Config, source string) scraper.ExtractorDefinition {
+	def := scraper.DefaultDefinition()
 
-	return &Application{
-		Serv: serv,
-		repo: repo,
+	e, ok := c.Extractors[source]
+	if !ok {
+		return def
 	}
+
+	return def.Merge(scraper.ExtractorDefinition{
+		Layout: scraper.Layout{
+			Container:   e.Container,
+			Title:       scraper.Field{Selector: e.Title.Selector, Index: e.Title.Index},
+			Details:     scraper.Field{Selector: e.Details.Selector, Index: e.Details.Index},
+			ReleaseDate: scraper.Field{Selector: e.ReleaseDate.Selector, Index: e.ReleaseDate.Index},
+			Description: scraper.Field{Selector: e.Description.Selector, Index: e.Description.Index},
+			Format:      e.Format,
+		},
+		Publisher:   e.Patterns.Publisher,
+		Pages:       e.Patterns.Pages,
+		Price:       e.Patterns.Price,
+		ReleaseDate: e.Patterns.ReleaseDate,
+		DateLayouts: e.DateLayouts,
+		Roles:       e.Roles,
+	})
 }
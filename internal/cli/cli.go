@@ -8,31 +8,42 @@ import (
 	"github.com/MikkelvtK/solipull/internal/service"
 	"github.com/charmbracelet/huh"
 	"github.com/urfave/cli/v3"
+	"golang.org/x/term"
 	"log/slog"
+	"os"
 	"slices"
 	"strings"
+	"time"
 )
 
 var (
-	allowedPublishers = []string{"dc", "marvel", "image"}
-	allowedMonths     = []string{"january", "february", "march", "april", "may", "june", "july", "august", "september",
+	allowedMonths = []string{"january", "february", "march", "april", "may", "june", "july", "august", "september",
 		"october", "november", "december"}
 )
 
 type CLI struct {
-	cmd        *cli.Command
-	solService *service.SolicitationService
+	cmd               *cli.Command
+	solService        *service.SolicitationService
+	creatorService    *service.CreatorService
+	collectionService *service.CollectionService
+	dbService         *service.DatabaseService
+	defaults          models.SyncDefaults
 
 	form    *huh.Form
 	metrics *models.AppMetrics
 	logger  *slog.Logger
 }
 
-func New(s *service.SolicitationService, m *models.AppMetrics, l *slog.Logger) *CLI {
+func New(s *service.SolicitationService, cs *service.CreatorService, col *service.CollectionService,
+	dbs *service.DatabaseService, d models.SyncDefaults, m *models.AppMetrics, l *slog.Logger) *CLI {
 	c := &CLI{
-		solService: s,
-		metrics:    m,
-		logger:     l,
+		solService:        s,
+		creatorService:    cs,
+		collectionService: col,
+		dbService:         dbs,
+		defaults:          d,
+		metrics:           m,
+		logger:            l,
 	}
 
 	c.cmd = &cli.Command{
@@ -44,6 +55,20 @@ func New(s *service.SolicitationService, m *models.AppMetrics, l *slog.Logger) *
 		},
 		Commands: []*cli.Command{
 			c.solicitation(),
+			c.creator(),
+			c.collection(),
+			c.db(),
+		},
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "no-input",
+				Usage: "Never prompt, use the flags and the defaults from the config or fail",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Confirm every question with yes, implies --no-input",
+			},
 		},
 	}
 
@@ -54,11 +79,24 @@ func (c *CLI) Run(ctx context.Context, args []string) error {
 	return c.cmd.Run(ctx, args)
 }
 
-func getPublishersUserInput(cmd *cli.Command) ([]string, error) {
+// isTerminal reports whether f is a terminal. Tests replace it to act like they run in one.
+var isTerminal = func(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// interactive reports whether solipull may prompt: stdin and stdout are terminals and neither --no-input nor --yes
+// is given. Running from cron, CI or a pipe never waits for input that does not come.
+func interactive(cmd *cli.Command) bool {
+	return !cmd.Bool("no-input") && !cmd.Bool("yes") && isTerminal(os.Stdin) && isTerminal(os.Stdout)
+}
+
+// getPublishersUserInput returns the publishers from the flag. Without it the user picks them, or the defaults from
+// the config are used when solipull can not prompt.
+func (c *CLI) getPublishersUserInput(cmd *cli.Command) ([]string, error) {
 	raw := cmd.StringSlice("publisher")
 
 	if len(raw) > 0 {
-		publishers, err := parseStringSliceFlag("publisher", raw, allowedPublishers)
+		publishers, err := parseStringSliceFlag("publisher", raw, c.solService.Publishers())
 		if err != nil {
 			return nil, err
 		}
@@ -66,17 +104,21 @@ func getPublishersUserInput(cmd *cli.Command) ([]string, error) {
 		return publishers, nil
 	}
 
+	if !interactive(cmd) {
+		if len(c.defaults.Publishers) == 0 {
+			return nil, errors.New("no publishers given, use --publisher or set sync.publishers in the config")
+		}
+
+		return parseStringSliceFlag("publisher", c.defaults.Publishers, c.solService.Publishers())
+	}
+
 	var input []string
 
 	form := huh.NewForm(
 		huh.NewGroup(
 			huh.NewMultiSelect[string]().
 				Title("Select your publishers").
-				Options(
-					huh.NewOption("DC", "dc"),
-					huh.NewOption("Marvel", "marvel"),
-					huh.NewOption("Image", "image"),
-				).
+				Options(publisherOptions(c.solService.Publishers())...).
 				Value(&input).
 				Validate(func(v []string) error {
 					if len(v) == 0 {
@@ -93,7 +135,9 @@ func getPublishersUserInput(cmd *cli.Command) ([]string, error) {
 	return input, nil
 }
 
-func getMonthsUserInput(cmd *cli.Command) ([]string, error) {
+// getMonthsUserInput returns the months from the flag. Without it the user picks them, or the defaults from the
+// config are used when solipull can not prompt.
+func (c *CLI) getMonthsUserInput(cmd *cli.Command) ([]string, error) {
 	raw := cmd.StringSlice("month")
 
 	if len(raw) > 0 {
@@ -105,6 +149,14 @@ func getMonthsUserInput(cmd *cli.Command) ([]string, error) {
 		return months, nil
 	}
 
+	if !interactive(cmd) {
+		if len(c.defaults.Months) == 0 {
+			return nil, errors.New("no months given, use --month or set sync.months in the config")
+		}
+
+		return parseStringSliceFlag("month", resolveMonths(c.defaults.Months, time.Now()), allowedMonths)
+	}
+
 	monthOptions := slices.Collect(func(yield func(huh.Option[string]) bool) {
 		for _, month := range allowedMonths {
 			if !yield(huh.NewOption(month, strings.ToLower(month))) {
@@ -134,6 +186,67 @@ func getMonthsUserInput(cmd *cli.Command) ([]string, error) {
 	return input, nil
 }
 
+// resolveMonths replaces "current" and "next" in the default months with the names of the months they stand for at
+// now.
+func resolveMonths(months []string, now time.Time) []string {
+	resolved := make([]string, 0, len(months))
+	for _, m := range months {
+		switch strings.ToLower(strings.TrimSpace(m)) {
+		case "current":
+			m = allowedMonths[now.Month()-1]
+		case "next":
+			m = allowedMonths[now.Month()%12]
+		}
+
+		resolved = append(resolved, m)
+	}
+
+	return resolved
+}
+
+// confirm asks the user to confirm an action. --yes confirms without asking. When solipull can not prompt the action
+// is refused, as nobody can answer.
+func confirm(cmd *cli.Command, title string) (bool, error) {
+	if cmd.Bool("yes") {
+		return true, nil
+	}
+
+	if !interactive(cmd) {
+		return false, fmt.Errorf("%q needs to be confirmed, rerun with --yes to confirm", title)
+	}
+
+	var ok bool
+
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(title).
+				Value(&ok),
+		),
+	)
+
+	if err := form.Run(); err != nil {
+		return false, err
+	}
+
+	return ok, nil
+}
+
+// publisherOptions returns the publishers to pick from, with the names publishers are known by.
+func publisherOptions(publishers []string) []huh.Option[string] {
+	options := make([]huh.Option[string], 0, len(publishers))
+	for _, p := range publishers {
+		label := strings.ToUpper(p[:1]) + p[1:]
+		if len(p) <= 2 {
+			label = strings.ToUpper(p)
+		}
+
+		options = append(options, huh.NewOption(label, p))
+	}
+
+	return options
+}
+
 func parseStringSliceFlag(flagName string, input, allowedValues []string) ([]string, error) {
 	if len(input) == 0 {
 		return nil, fmt.Errorf("invalid %s input", flagName)
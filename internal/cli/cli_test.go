@@ -0,0 +1,235 @@
+package cli
+
+import (
+	"context"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/MikkelvtK/solipull/internal/service"
+	"github.com/urfave/cli/v3"
+	"log/slog"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeProvider is a source with solicitations of a single publisher.
+type fakeProvider struct {
+	publisher string
+}
+
+func (f fakeProvider) Name() string {
+	return f.publisher
+}
+
+func (f fakeProvider) StartURL() string {
+	return "https://" + f.publisher + ".com"
+}
+
+func (f fakeProvider) Publishers() []string {
+	return []string{f.publisher}
+}
+
+func (f fakeProvider) GetData(_ context.Context, _ string, res chan<- models.ComicBook, _ service.ScrapingObserver) error {
+	close(res)
+	return nil
+}
+
+func (f fakeProvider) Preview(_ context.Context, _ string, res chan<- models.ComicBook, _ service.ScrapingObserver) error {
+	close(res)
+	return nil
+}
+
+func (f fakeProvider) Resume(_ context.Context, res chan<- models.ComicBook, _ service.ScrapingObserver) error {
+	close(res)
+	return nil
+}
+
+func (f fakeProvider) GetPages(_ context.Context, _ []string, _ string, res chan<- models.ComicBook,
+	_ service.ScrapingObserver) error {
+	close(res)
+	return nil
+}
+
+func (f fakeProvider) SetInputs(_, _ []string) error {
+	return nil
+}
+
+// runInput runs a command under a CLI with the defaults, so the root flags are parsed like they are for a real
+// command, and returns what action returned.
+func runInput(d models.SyncDefaults, args []string, action func(c *CLI, cmd *cli.Command) error) error {
+	r, err := service.NewProviderRegistry(fakeProvider{"dc"}, fakeProvider{"marvel"}, fakeProvider{"image"},
+		fakeProvider{"boom"})
+	if err != nil {
+		return err
+	}
+
+	s := service.NewSolicitationService(r, nil, nil, nil)
+	c := New(s, nil, nil, nil, d, &models.AppMetrics{}, slog.Default())
+	c.cmd.Commands = append(c.cmd.Commands, &cli.Command{
+		Name: "test",
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return action(c, cmd)
+		},
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{Name: "publisher"},
+			&cli.StringSliceFlag{Name: "month"},
+		},
+	})
+
+	return c.Run(context.Background(), append([]string{"solipull", "test"}, args...))
+}
+
+func withTerminal(terminal bool, t *testing.T) {
+	t.Helper()
+
+	old := isTerminal
+	isTerminal = func(*os.File) bool { return terminal }
+	t.Cleanup(func() { isTerminal = old })
+}
+
+func TestCLI_getUserInput(t *testing.T) {
+	tests := []struct {
+		name           string
+		terminal       bool
+		defaults       models.SyncDefaults
+		args           []string
+		wantPublishers []string
+		wantMonths     []string
+		wantErr        bool
+	}{
+		{
+			name:           "flags",
+			args:           []string{"--publisher", "dc", "--month", "march"},
+			wantPublishers: []string{"dc"},
+			wantMonths:     []string{"march"},
+		},
+		{
+			name:           "defaults without a terminal",
+			defaults:       models.SyncDefaults{Publishers: []string{"Image"}, Months: []string{"current", "next"}},
+			wantPublishers: []string{"image"},
+			wantMonths:     []string{allowedMonths[time.Now().Month()-1], allowedMonths[time.Now().Month()%12]},
+		},
+		{
+			name:           "defaults with --no-input",
+			terminal:       true,
+			defaults:       models.SyncDefaults{Publishers: []string{"marvel"}, Months: []string{"may"}},
+			args:           []string{"--no-input"},
+			wantPublishers: []string{"marvel"},
+			wantMonths:     []string{"may"},
+		},
+		{
+			name:           "publisher of a configured source",
+			args:           []string{"--publisher", "boom", "--month", "june"},
+			wantPublishers: []string{"boom"},
+			wantMonths:     []string{"june"},
+		},
+		{
+			name:    "publisher without a source",
+			args:    []string{"--publisher", "dark horse", "--month", "june"},
+			wantErr: true,
+		},
+		{
+			name:    "no defaults without a terminal",
+			wantErr: true,
+		},
+		{
+			name:     "invalid defaults",
+			defaults: models.SyncDefaults{Publishers: []string{"dynamite"}, Months: []string{"may"}},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withTerminal(tt.terminal, t)
+
+			var publishers, months []string
+			err := runInput(tt.defaults, tt.args, func(c *CLI, cmd *cli.Command) error {
+				var err error
+				if publishers, err = c.getPublishersUserInput(cmd); err != nil {
+					return err
+				}
+
+				months, err = c.getMonthsUserInput(cmd)
+				return err
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getUserInput() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(publishers, tt.wantPublishers) || !reflect.DeepEqual(months, tt.wantMonths) {
+				t.Errorf("getUserInput() = %v, %v, want %v, %v", publishers, months, tt.wantPublishers,
+					tt.wantMonths)
+			}
+		})
+	}
+}
+
+func Test_resolveMonths(t *testing.T) {
+	tests := []struct {
+		name   string
+		months []string
+		now    time.Time
+		want   []string
+	}{
+		{
+			name:   "names",
+			months: []string{"january", "May"},
+			now:    time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+			want:   []string{"january", "May"},
+		},
+		{
+			name:   "current and next",
+			months: []string{"current", " Next"},
+			now:    time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+			want:   []string{"march", "april"},
+		},
+		{
+			name:   "next wraps to january",
+			months: []string{"current", "next"},
+			now:    time.Date(2026, 12, 15, 0, 0, 0, 0, time.UTC),
+			want:   []string{"december", "january"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveMonths(tt.months, tt.now); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolveMonths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_confirm(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    bool
+		wantErr bool
+	}{
+		{name: "yes", args: []string{"--yes"}, want: true},
+		{name: "short yes", args: []string{"-y"}, want: true},
+		{name: "no input", args: []string{"--no-input"}, wantErr: true},
+		{name: "no terminal", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withTerminal(false, t)
+
+			var got bool
+			err := runInput(models.SyncDefaults{}, tt.args, func(_ *CLI, cmd *cli.Command) error {
+				var err error
+				got, err = confirm(cmd, "Delete 3 books?")
+				return err
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("confirm() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("confirm() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/MikkelvtK/solipull/internal/service"
+	"github.com/urfave/cli/v3"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+func bookPublisherFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:    "publisher",
+		Aliases: []string{"p"},
+		Usage:   "Publisher of the book, needed when the title and issue match more than one book",
+	}
+}
+
+func (c *CLI) collection() *cli.Command {
+	return &cli.Command{
+		Name:  "collection",
+		Usage: "Track the books you want, ordered, own and have read",
+		Description: "Books are referred to as they are listed, like \"Batman #1\". Wanted and ordered books make up " +
+			"your pull list.",
+		Before: c.requireDatabase,
+		Commands: []*cli.Command{
+			c.collectionAdd(),
+			c.collectionRemove(),
+			c.collectionList(),
+			c.collectionStatus(),
+			c.collectionGaps(),
+			c.collectionImport(),
+		},
+	}
+}
+
+func (c *CLI) collectionAdd() *cli.Command {
+	return &cli.Command{
+		Name:      "add",
+		Usage:     "Add a book to the collection, or update its entry.",
+		ArgsUsage: "<title #issue>",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			ref, err := bookRefArg(cmd)
+			if err != nil {
+				return err
+			}
+
+			item := models.CollectionItem{
+				Status:        strings.ToLower(cmd.String("status")),
+				Condition:     cmd.String("condition"),
+				PurchasePrice: cmd.Float("price"),
+				Location:      cmd.String("location"),
+			}
+
+			if d := cmd.String("date"); d != "" {
+				item.PurchaseDate, err = time.Parse(dateLayout, d)
+				if err != nil {
+					return fmt.Errorf("invalid --date %q, expected YYYY-MM-DD", d)
+				}
+			}
+
+			item, err = c.collectionService.Add(ctx, ref, item)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("✔ Added %s to the collection as %s.\n", bookTitle(item.ComicBook), item.Status)
+			return nil
+		},
+		Flags: []cli.Flag{
+			bookPublisherFlag(),
+			&cli.StringFlag{
+				Name:    "status",
+				Aliases: []string{"s"},
+				Value:   models.StatusOwned,
+				Usage:   "One of " + strings.Join(models.CollectionStatuses, ", "),
+			},
+			&cli.StringFlag{
+				Name:  "condition",
+				Usage: "Condition or grade of the copy, like NM or 9.8",
+			},
+			&cli.FloatFlag{
+				Name:  "price",
+				Usage: "Price paid for the copy",
+			},
+			&cli.StringFlag{
+				Name:  "date",
+				Usage: "Date the copy was bought (YYYY-MM-DD)",
+			},
+			&cli.StringFlag{
+				Name:  "location",
+				Usage: "Where the copy is stored, like a long box",
+			},
+		},
+	}
+}
+
+func (c *CLI) collectionRemove() *cli.Command {
+	return &cli.Command{
+		Name:      "remove",
+		Usage:     "Remove a book from the collection.",
+		ArgsUsage: "<title #issue>",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			ref, err := bookRefArg(cmd)
+			if err != nil {
+				return err
+			}
+
+			if err := c.collectionService.Remove(ctx, ref); err != nil {
+				return err
+			}
+
+			fmt.Printf("✔ Removed %s from the collection.\n", ref)
+			return nil
+		},
+		Flags: []cli.Flag{bookPublisherFlag()},
+	}
+}
+
+func (c *CLI) collectionStatus() *cli.Command {
+	return &cli.Command{
+		Name:      "status",
+		Usage:     "Change the status of a book in the collection.",
+		ArgsUsage: "<title #issue> <" + strings.Join(models.CollectionStatuses, "|") + ">",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.NArg() != 2 {
+				return errors.New("expected a book and a status")
+			}
+
+			ref := service.ParseBookRef(cmd.Args().Get(0), cmd.String("publisher"))
+			status := strings.ToLower(cmd.Args().Get(1))
+
+			if err := c.collectionService.SetStatus(ctx, ref, status); err != nil {
+				return err
+			}
+
+			fmt.Printf("✔ Marked %s as %s.\n", ref, status)
+			return nil
+		},
+		Flags: []cli.Flag{bookPublisherFlag()},
+	}
+}
+
+func (c *CLI) collectionList() *cli.Command {
+	return &cli.Command{
+		Name:  "list",
+		Usage: "List the books in the collection.",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			items, err := c.collectionService.List(ctx, strings.ToLower(cmd.String("status")))
+			if err != nil {
+				return err
+			}
+
+			return writeCollectionTable(os.Stdout, items)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "status",
+				Aliases: []string{"s"},
+				Usage:   "Only list books with this status",
+			},
+		},
+	}
+}
+
+func bookRefArg(cmd *cli.Command) (service.BookRef, error) {
+	if cmd.NArg() != 1 {
+		return service.BookRef{}, errors.New("expected a single book like \"Batman #1\"")
+	}
+
+	return service.ParseBookRef(cmd.Args().First(), cmd.String("publisher")), nil
+}
+
+func writeCollectionTable(w io.Writer, items []models.CollectionItem) error {
+	if len(items) == 0 {
+		_, err := fmt.Fprintln(w, "The collection is empty.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "\tTITLE\tPUBLISHER\tRELEASE\tSTATUS\tCONDITION\tPAID\tBOUGHT\tLOCATION\n")
+
+	for _, it := range items {
+		paid, bought := "", ""
+		if it.PurchasePrice > 0 {
+			paid = fmt.Sprintf("$%.2f", it.PurchasePrice)
+		}
+		if !it.PurchaseDate.IsZero() {
+			bought = it.PurchaseDate.Format(dateLayout)
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", statusMarker(it.Status), bookTitle(it.ComicBook),
+			strings.ToUpper(it.Publisher), releaseDate(it.ComicBook), it.Status, it.Condition, paid, bought,
+			it.Location)
+	}
+
+	return tw.Flush()
+}
+
+// statusMarker is the symbol a book in the collection is marked with in lists.
+func statusMarker(status string) string {
+	switch status {
+	case models.StatusWanted:
+		return "☆"
+	case models.StatusOrdered:
+		return "◔"
+	case models.StatusOwned:
+		return "●"
+	case models.StatusRead:
+		return "✔"
+	default:
+		return ""
+	}
+}
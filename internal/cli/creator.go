@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/urfave/cli/v3"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+func (c *CLI) creator() *cli.Command {
+	return &cli.Command{
+		Name:    "creator",
+		Aliases: []string{"creators"},
+		Usage:   "Manage the creators books are credited to",
+		Before:  c.requireDatabase,
+		Commands: []*cli.Command{
+			c.creatorList(),
+			c.creatorAlias(),
+			c.creatorMerge(),
+			c.creatorFollow(),
+			c.creatorUnfollow(),
+			c.creatorFollowing(),
+			c.creatorUpcoming(),
+		},
+	}
+}
+
+func (c *CLI) creatorList() *cli.Command {
+	return &cli.Command{
+		Name:  "list",
+		Usage: "List creators with their aliases.",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			people, err := c.creatorService.List(ctx, cmd.String("search"))
+			if err != nil {
+				return err
+			}
+
+			return writePeopleTable(os.Stdout, people)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "search",
+				Usage: "Only list creators with an alias containing this text",
+			},
+		},
+	}
+}
+
+func (c *CLI) creatorAlias() *cli.Command {
+	return &cli.Command{
+		Name:      "alias",
+		Usage:     "Credit books listed under another spelling to a creator.",
+		ArgsUsage: "<name> <alias>",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.NArg() != 2 {
+				return errors.New("expected a name and an alias")
+			}
+
+			name, alias := cmd.Args().Get(0), cmd.Args().Get(1)
+			if err := c.creatorService.Alias(ctx, name, alias); err != nil {
+				return err
+			}
+
+			fmt.Printf("✔ %s now resolves to %s.\n", alias, name)
+			return nil
+		},
+	}
+}
+
+func (c *CLI) creatorMerge() *cli.Command {
+	return &cli.Command{
+		Name:      "merge",
+		Usage:     "Merge two creators that are the same person.",
+		ArgsUsage: "<from> <into>",
+		Description: "Moves the aliases and credits of <from> to <into> and removes <from>. Credits are renamed " +
+			"to the name of <into>.",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.NArg() != 2 {
+				return errors.New("expected the creator to merge and the creator to merge into")
+			}
+
+			from, into := cmd.Args().Get(0), cmd.Args().Get(1)
+			if err := c.creatorService.Merge(ctx, from, into); err != nil {
+				return err
+			}
+
+			fmt.Printf("✔ Merged %s into %s.\n", from, into)
+			return nil
+		},
+	}
+}
+
+func writePeopleTable(w io.Writer, people []models.Person) error {
+	if len(people) == 0 {
+		_, err := fmt.Fprintln(w, "No creators found.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "NAME\tBOOKS\tALIASES\n")
+
+	for _, p := range people {
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", p.Name, p.Books, strings.Join(p.Aliases, ", "))
+	}
+
+	return tw.Flush()
+}
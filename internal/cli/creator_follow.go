@@ -0,0 +1,213 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/urfave/cli/v3"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+func (c *CLI) creatorFollow() *cli.Command {
+	return &cli.Command{
+		Name:      "follow",
+		Usage:     "Follow a creator to track their upcoming books.",
+		ArgsUsage: "<name>",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.NArg() != 1 {
+				return errors.New("expected the name of a creator")
+			}
+
+			name := cmd.Args().First()
+			if err := c.creatorService.Follow(ctx, name, cmd.StringSlice("role")); err != nil {
+				return err
+			}
+
+			fmt.Printf("✔ Following %s.\n", name)
+			return nil
+		},
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:    "role",
+				Aliases: []string{"r"},
+				Usage:   "Only track books the creator has one of these roles on",
+			},
+		},
+	}
+}
+
+func (c *CLI) creatorUnfollow() *cli.Command {
+	return &cli.Command{
+		Name:      "unfollow",
+		Usage:     "Stop following a creator.",
+		ArgsUsage: "<name>",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.NArg() != 1 {
+				return errors.New("expected the name of a creator")
+			}
+
+			name := cmd.Args().First()
+			if err := c.creatorService.Unfollow(ctx, name); err != nil {
+				return err
+			}
+
+			fmt.Printf("✔ No longer following %s.\n", name)
+			return nil
+		},
+	}
+}
+
+func (c *CLI) creatorFollowing() *cli.Command {
+	return &cli.Command{
+		Name:  "following",
+		Usage: "List the creators you follow.",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			follows, err := c.creatorService.Following(ctx)
+			if err != nil {
+				return err
+			}
+
+			if len(follows) == 0 {
+				fmt.Println("You are not following any creators.")
+				return nil
+			}
+
+			tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(tw, "NAME\tROLES\n")
+
+			for _, f := range follows {
+				roles := "any"
+				if len(f.Roles) > 0 {
+					roles = strings.Join(f.Roles, ", ")
+				}
+
+				fmt.Fprintf(tw, "%s\t%s\n", f.Name, roles)
+			}
+
+			return tw.Flush()
+		},
+	}
+}
+
+func (c *CLI) creatorUpcoming() *cli.Command {
+	return &cli.Command{
+		Name:  "upcoming",
+		Usage: "List stored books featuring the creators you follow.",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			from, to, err := parseDateRange(cmd.String("from"), cmd.String("to"), time.Now())
+			if err != nil {
+				return err
+			}
+
+			cbs, err := c.creatorService.Upcoming(ctx, from, to)
+			if err != nil {
+				return err
+			}
+
+			if len(cbs) == 0 {
+				fmt.Printf("No books from followed creators between %s and %s.\n", from.Format(dateLayout),
+					to.Format(dateLayout))
+				return nil
+			}
+
+			return writeCreditsTable(os.Stdout, cbs)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "from",
+				Usage: "First release date to include (YYYY-MM-DD), defaults to today",
+			},
+			&cli.StringFlag{
+				Name:  "to",
+				Usage: "Last release date to include (YYYY-MM-DD), defaults to four weeks after --from",
+			},
+		},
+	}
+}
+
+// parseDateRange turns the inclusive dates of a flag into the half-open range [from, to) the services expect.
+func parseDateRange(fromFlag, toFlag string, now time.Time) (time.Time, time.Time, error) {
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if fromFlag != "" {
+		t, err := time.Parse(dateLayout, fromFlag)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --from date %q, expected YYYY-MM-DD", fromFlag)
+		}
+		from = t
+	}
+
+	to := from.AddDate(0, 0, 28)
+	if toFlag != "" {
+		t, err := time.Parse(dateLayout, toFlag)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --to date %q, expected YYYY-MM-DD", toFlag)
+		}
+		to = t.AddDate(0, 0, 1)
+	}
+
+	return from, to, nil
+}
+
+func writeCreditsTable(w io.Writer, cbs []models.ComicBook) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "RELEASE\tTITLE\tPUBLISHER\tFORMAT\tCREATORS\n")
+
+	for _, cb := range cbs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", releaseDate(cb), bookTitle(cb), strings.ToUpper(cb.Publisher),
+			cb.Format, formatCredits(cb.Creators))
+	}
+
+	return tw.Flush()
+}
+
+// formatCredits lists every creator once with all their roles, like "Tom King (writer, cover artist)".
+func formatCredits(creators []models.Creator) string {
+	return strings.Join(credits(creators), ", ")
+}
+
+// credits returns the credit of every creator in the order they first appear.
+func credits(creators []models.Creator) []string {
+	var names []string
+	roles := make(map[string][]string)
+
+	for _, cr := range creators {
+		if _, ok := roles[cr.Name]; !ok {
+			names = append(names, cr.Name)
+		}
+
+		roles[cr.Name] = append(roles[cr.Name], cr.Role)
+	}
+
+	credits := make([]string, 0, len(names))
+	for _, n := range names {
+		credits = append(credits, fmt.Sprintf("%s (%s)", n, strings.Join(roles[n], ", ")))
+	}
+
+	return credits
+}
+
+// reportFollowed prints the books from followed creators that were stored for the first time since the sync started.
+func (c *CLI) reportFollowed(ctx context.Context, since time.Time) error {
+	if c.creatorService == nil {
+		return nil
+	}
+
+	cbs, err := c.creatorService.NewlySolicited(ctx, since)
+	if err != nil {
+		return err
+	}
+
+	if len(cbs) == 0 {
+		return nil
+	}
+
+	fmt.Printf("\n★ %d new books from creators you follow:\n\n", len(cbs))
+	return writeCreditsTable(os.Stdout, cbs)
+}
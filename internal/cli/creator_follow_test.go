@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"github.com/MikkelvtK/solipull/internal/models"
+	"testing"
+	"time"
+)
+
+func Test_parseDateRange(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		wantFrom time.Time
+		wantTo   time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "defaults to the next four weeks",
+			wantFrom: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2026, 4, 7, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "includes the end date",
+			from:     "2026-03-01",
+			to:       "2026-03-31",
+			wantFrom: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "invalid date",
+			from:    "03/01/2026",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := parseDateRange(tt.from, tt.to, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDateRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo) {
+				t.Errorf("parseDateRange() got = %v - %v, want %v - %v", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func Test_formatCredits(t *testing.T) {
+	creators := []models.Creator{
+		{Name: "Fiona Staples", Role: models.RoleArtist},
+		{Name: "Brian K. Vaughan", Role: models.RoleWriter},
+		{Name: "Fiona Staples", Role: models.RoleCoverArtist},
+	}
+
+	want := "Fiona Staples (artist, cover artist), Brian K. Vaughan (writer)"
+	if got := formatCredits(creators); got != want {
+		t.Errorf("formatCredits() = %q, want %q", got, want)
+	}
+}
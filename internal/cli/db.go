@@ -0,0 +1,410 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/urfave/cli/v3"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+)
+
+func (c *CLI) db() *cli.Command {
+	return &cli.Command{
+		Name:  "db",
+		Usage: "Inspect, migrate, back up and restore the local database",
+		Commands: []*cli.Command{
+			c.dbStatus(),
+			c.dbMigrate(),
+			c.dbCheck(),
+			c.dbVacuum(),
+			c.dbPrune(),
+			c.dbBackup(),
+			c.dbRestore(),
+			c.dbDump(),
+			c.dbLoad(),
+		},
+	}
+}
+
+// requireDatabase migrates the database before the commands that use the data. When that fails the command is
+// stopped, pointing to the commands that help to find out why.
+func (c *CLI) requireDatabase(ctx context.Context, _ *cli.Command) (context.Context, error) {
+	if err := c.dbService.Prepare(ctx); err != nil {
+		return ctx, fmt.Errorf("%v\nrun 'solipull db status' to see which migrations are applied, 'solipull db check' "+
+			"to look for damage or 'solipull db restore' to go back to a backup", err)
+	}
+
+	return ctx, nil
+}
+
+func (c *CLI) dbStatus() *cli.Command {
+	return &cli.Command{
+		Name:  "status",
+		Usage: "List the applied and pending migrations.",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			migrations, err := c.dbService.Migrations(ctx)
+			if err != nil {
+				return err
+			}
+
+			return writeMigrations(os.Stdout, migrations)
+		},
+	}
+}
+
+func (c *CLI) dbMigrate() *cli.Command {
+	return &cli.Command{
+		Name:  "migrate",
+		Usage: "Apply or roll back migrations.",
+		Description: "The database is backed up before its schema changes. Rolling back drops the tables and columns " +
+			"the rolled back migrations added, together with their data.",
+		Commands: []*cli.Command{
+			{
+				Name:  "up",
+				Usage: "Apply all pending migrations.",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return c.migrate(ctx, cmd, -1, c.dbService.MigrateUp)
+				},
+			},
+			{
+				Name:  "down",
+				Usage: "Roll back the newest applied migration.",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return c.migrate(ctx, cmd, 0, c.dbService.MigrateDown)
+				},
+			},
+			{
+				Name:      "to",
+				Usage:     "Apply or roll back migrations until the schema is at a version.",
+				ArgsUsage: "<version>",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if cmd.NArg() != 1 {
+						return errors.New("expected a schema version")
+					}
+
+					version, err := strconv.ParseInt(cmd.Args().First(), 10, 64)
+					if err != nil {
+						return fmt.Errorf("invalid schema version: %s", cmd.Args().First())
+					}
+
+					return c.migrate(ctx, cmd, version, func(ctx context.Context) (string, error) {
+						return c.dbService.MigrateTo(ctx, version)
+					})
+				},
+			},
+		},
+	}
+}
+
+// migrate runs a migration and reports the schema version before and after it. Rollbacks below the current version
+// are confirmed first, as they drop data. A negative target never rolls back.
+func (c *CLI) migrate(ctx context.Context, cmd *cli.Command, target int64,
+	run func(ctx context.Context) (string, error)) error {
+	before, err := c.schemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if target >= 0 && target < before {
+		ok, err := confirm(cmd, fmt.Sprintf("Roll back the database from schema %d? Data added by later migrations is "+
+			"dropped.", before))
+		if err != nil || !ok {
+			return err
+		}
+	}
+
+	backup, err := run(ctx)
+	if err != nil {
+		if backup != "" {
+			return fmt.Errorf("%v, the database before migrating was backed up to %s", err, backup)
+		}
+		return err
+	}
+
+	after, err := c.schemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if after == before {
+		fmt.Printf("The database is already at schema %d\n", after)
+		return nil
+	}
+
+	fmt.Printf("✔ Migrated the database from schema %d to %d\n", before, after)
+	if backup != "" {
+		fmt.Printf("  The previous database was backed up to %s\n", backup)
+	}
+
+	return nil
+}
+
+func (c *CLI) schemaVersion(ctx context.Context) (int64, error) {
+	migrations, err := c.dbService.Migrations(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var version int64
+	for _, m := range migrations {
+		if m.Applied {
+			version = m.Version
+		}
+	}
+
+	return version, nil
+}
+
+func (c *CLI) dbCheck() *cli.Command {
+	return &cli.Command{
+		Name:  "check",
+		Usage: "Look for corruption, broken references and orphaned creators.",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			report, err := c.dbService.Check(ctx)
+			if err != nil {
+				return err
+			}
+
+			if err := writeIntegrityReport(os.Stdout, report); err != nil {
+				return err
+			}
+
+			if !report.Healthy() {
+				return errors.New("the database check found problems")
+			}
+
+			return nil
+		},
+	}
+}
+
+func (c *CLI) dbVacuum() *cli.Command {
+	return &cli.Command{
+		Name:  "vacuum",
+		Usage: "Rebuild the database file to reclaim unused space.",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			before, after, err := c.dbService.Vacuum(ctx)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("✔ Vacuumed the database from %s to %s\n", formatSize(before), formatSize(after))
+			return nil
+		},
+	}
+}
+
+func (c *CLI) dbBackup() *cli.Command {
+	return &cli.Command{
+		Name:  "backup",
+		Usage: "Copy the database to a backup file.",
+		Description: "Makes a consistent copy of the database, even while another solipull command is using it. " +
+			"Without --out, the copy is written to a timestamped file in the backups directory next to the database.",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			path, err := c.dbService.Backup(ctx, cmd.String("out"))
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("✔ Backed up the database to %s\n", path)
+			return nil
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "out",
+				Aliases: []string{"o"},
+				Usage:   "File to write the backup to",
+			},
+		},
+	}
+}
+
+func (c *CLI) dbRestore() *cli.Command {
+	return &cli.Command{
+		Name:      "restore",
+		Usage:     "Replace the database with a backup.",
+		ArgsUsage: "<backup.db>",
+		Description: "The current database is backed up first. Backups made by an older version of solipull are " +
+			"migrated the next time it runs.",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.NArg() != 1 {
+				return errors.New("expected a backup file")
+			}
+
+			src := cmd.Args().First()
+
+			ok, err := confirm(cmd, fmt.Sprintf("Replace the database with %s?", src))
+			if err != nil || !ok {
+				return err
+			}
+
+			if err := c.dbService.Restore(ctx, src); err != nil {
+				return err
+			}
+
+			fmt.Printf("✔ Restored the database from %s\n", src)
+			return nil
+		},
+	}
+}
+
+func (c *CLI) dbDump() *cli.Command {
+	return &cli.Command{
+		Name:  "dump",
+		Usage: "Write all data to a portable JSON document.",
+		Description: "The dump holds the comic books with their creators, creator aliases, follows and the " +
+			"collection. It is versioned and does not depend on the database schema, so it can be loaded by other " +
+			"versions of solipull.",
+		Before: c.requireDatabase,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			out := os.Stdout
+			if path := cmd.String("out"); path != "" {
+				f, err := os.Create(path)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+
+				out = f
+			}
+
+			return c.dbService.Dump(ctx, out)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "out",
+				Aliases: []string{"o"},
+				Usage:   "File to write the dump to, defaults to stdout",
+			},
+		},
+	}
+}
+
+func (c *CLI) dbLoad() *cli.Command {
+	return &cli.Command{
+		Name:        "load",
+		Usage:       "Merge a JSON dump into the database.",
+		ArgsUsage:   "<dump.json>",
+		Description: "The database is backed up before loading. Books and collection entries in the dump replace the stored ones.",
+		Before:      c.requireDatabase,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.NArg() != 1 {
+				return errors.New("expected a dump file")
+			}
+
+			f, err := os.Open(cmd.Args().First())
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			var dump models.Dump
+			if err := json.NewDecoder(f).Decode(&dump); err != nil {
+				return fmt.Errorf("failed to read dump: %v", err)
+			}
+
+			backup, err := c.dbService.Load(ctx, dump)
+			if err != nil {
+				if backup != "" {
+					return fmt.Errorf("%v, the database before loading was backed up to %s", err, backup)
+				}
+				return err
+			}
+
+			fmt.Printf("✔ Loaded %d books and %d collection entries, the previous database was backed up to %s\n",
+				len(dump.ComicBooks), len(dump.Collection), backup)
+			return nil
+		},
+	}
+}
+
+func writeMigrations(w io.Writer, migrations []models.Migration) error {
+	var applied int64
+	pending := 0
+	for _, m := range migrations {
+		if m.Applied {
+			applied = m.Version
+		} else {
+			pending++
+		}
+	}
+
+	fmt.Fprintf(w, "Schema version %d, %d pending migrations\n\n", applied, pending)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "VERSION\tNAME\tSTATUS\tAPPLIED AT\n")
+
+	for _, m := range migrations {
+		status, at := "pending", ""
+		if m.Applied {
+			status = "applied"
+		}
+
+		if !m.AppliedAt.IsZero() {
+			at = m.AppliedAt.Local().Format("2006-01-02 15:04")
+		}
+
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", m.Version, m.Name, status, at)
+	}
+
+	return tw.Flush()
+}
+
+func writeIntegrityReport(w io.Writer, r models.IntegrityReport) error {
+	if r.Healthy() {
+		_, err := fmt.Fprintln(w, "✔ No problems found")
+		return err
+	}
+
+	for _, msg := range r.Integrity {
+		fmt.Fprintf(w, "✘ %s\n", msg)
+	}
+
+	type reference struct{ table, parent string }
+
+	var refs []reference
+	counts := make(map[reference]int)
+	for _, v := range r.ForeignKeys {
+		ref := reference{v.Table, v.Parent}
+		if counts[ref] == 0 {
+			refs = append(refs, ref)
+		}
+		counts[ref]++
+	}
+
+	for _, ref := range refs {
+		fmt.Fprintf(w, "✘ %d rows in %s refer to missing rows in %s\n", counts[ref], ref.table, ref.parent)
+	}
+
+	if r.OrphanCreators > 0 {
+		fmt.Fprintf(w, "✘ %d creators belong to books that no longer exist\n", r.OrphanCreators)
+	}
+
+	if r.UnlinkedCreators > 0 {
+		fmt.Fprintf(w, "✘ %d creators are not linked to a person, the next command that uses the data links them\n",
+			r.UnlinkedCreators)
+	}
+
+	return nil
+}
+
+// formatSize formats a number of bytes with a binary unit.
+func formatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
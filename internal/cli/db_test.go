@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"bytes"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"testing"
+	"time"
+)
+
+func Test_formatSize(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{n: 512, want: "512 B"},
+		{n: 4096, want: "4.0 KiB"},
+		{n: 5 << 20, want: "5.0 MiB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatSize(tt.n); got != tt.want {
+				t.Errorf("formatSize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_writeIntegrityReport(t *testing.T) {
+	tests := []struct {
+		name   string
+		report models.IntegrityReport
+		want   string
+	}{
+		{
+			name: "healthy",
+			want: "✔ No problems found\n",
+		},
+		{
+			name: "groups foreign key violations",
+			report: models.IntegrityReport{
+				ForeignKeys: []models.ForeignKeyViolation{
+					{Table: "follows", RowID: 1, Parent: "people"},
+					{Table: "follows", RowID: 2, Parent: "people"},
+				},
+				OrphanCreators: 3,
+			},
+			want: "✘ 2 rows in follows refer to missing rows in people\n" +
+				"✘ 3 creators belong to books that no longer exist\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeIntegrityReport(&buf, tt.report); err != nil {
+				t.Fatalf("writeIntegrityReport() error = %v", err)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("writeIntegrityReport() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_writePruneSummary(t *testing.T) {
+	var buf bytes.Buffer
+	summary := models.PruneSummary{
+		Before:   time.Date(2024, 10, 19, 0, 0, 0, 0, time.UTC),
+		Books:    120,
+		Creators: 310,
+		Kept:     4,
+	}
+
+	if err := writePruneSummary(&buf, summary); err != nil {
+		t.Fatalf("writePruneSummary() error = %v", err)
+	}
+
+	want := "Books released before 2024-10-19:\n" +
+		"  120 books with 310 credits would be deleted\n" +
+		"  4 books are kept as they are in your collection or on your pull list\n"
+	if got := buf.String(); got != want {
+		t.Errorf("writePruneSummary() got = %q, want %q", got, want)
+	}
+}
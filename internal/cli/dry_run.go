@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/urfave/cli/v3"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+func (c *CLI) dryRun(ctx context.Context, cmd *cli.Command, months, publishers []string) error {
+	rep := newSyncReporter(c.metrics, c.logger)
+	if cmd.Bool("json") {
+		rep.out = os.Stderr
+	}
+
+	diff, err := c.solService.DryRun(ctx, rep, cmd.String("source"), months, publishers)
+	if err != nil {
+		return syncError(err)
+	}
+
+	if err := rep.finish(); err != nil {
+		return err
+	}
+
+	if cmd.Bool("json") {
+		return writeDiffJSON(os.Stdout, diff)
+	}
+
+	return writeDiffTable(os.Stdout, diff)
+}
+
+func writeDiffJSON(w io.Writer, diff *models.SyncDiff) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(diff)
+}
+
+func writeDiffTable(w io.Writer, diff *models.SyncDiff) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "CHANGE\tTITLE\tPUBLISHER\tRELEASE\tDETAILS\n")
+
+	for _, cb := range diff.New {
+		fmt.Fprintf(tw, "+ new\t%s\t%s\t%s\t%s %s\n", bookTitle(cb), strings.ToUpper(cb.Publisher),
+			releaseDate(cb), cb.Price, cb.Format)
+	}
+
+	for _, ch := range diff.Changed {
+		for i, f := range ch.Fields {
+			title, pub, rd := "", "", ""
+			if i == 0 {
+				title, pub, rd = bookTitle(ch.ComicBook), strings.ToUpper(ch.ComicBook.Publisher), releaseDate(ch.ComicBook)
+			}
+
+			fmt.Fprintf(tw, "~ changed\t%s\t%s\t%s\t%s: %q → %q\n", title, pub, rd, f.Field, f.Old, f.New)
+		}
+	}
+
+	for _, cb := range diff.Removed {
+		fmt.Fprintf(tw, "- removed\t%s\t%s\t%s\t\n", bookTitle(cb), strings.ToUpper(cb.Publisher), releaseDate(cb))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d new, %d changed, %d no longer present. Nothing was saved.\n",
+		len(diff.New), len(diff.Changed), len(diff.Removed))
+	return err
+}
+
+func bookTitle(cb models.ComicBook) string {
+	if cb.Issue == "" {
+		return cb.Title
+	}
+
+	return fmt.Sprintf("%s #%s", cb.Title, cb.Issue)
+}
+
+func releaseDate(cb models.ComicBook) string {
+	if cb.ReleaseDate.IsZero() {
+		return "-"
+	}
+
+	return cb.ReleaseDate.Format("2006-01-02")
+}
@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/urfave/cli/v3"
+	"io"
+	"iter"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// viewQuery builds the book query from the view flags. Unlike sync, view does not ask for publishers and months:
+// leaving them out shows every book.
+func (c *CLI) viewQuery(cmd *cli.Command) (models.BookQuery, error) {
+	q := models.BookQuery{
+		Search: cmd.String("search"),
+		Sort:   models.BookSort(cmd.String("sort")),
+		Limit:  int(cmd.Int("limit")),
+		Offset: int(cmd.Int("offset")),
+	}
+
+	if !slices.Contains(models.BookSorts, q.Sort) {
+		return q, fmt.Errorf("invalid sort specified: %s", q.Sort)
+	}
+
+	if raw := cmd.StringSlice("publisher"); len(raw) > 0 {
+		publishers, err := parseStringSliceFlag("publisher", raw, c.solService.Publishers())
+		if err != nil {
+			return q, err
+		}
+
+		q.Publishers = publishers
+	}
+
+	if raw := cmd.StringSlice("month"); len(raw) > 0 {
+		months, err := parseStringSliceFlag("month", raw, allowedMonths)
+		if err != nil {
+			return q, err
+		}
+
+		for _, m := range months {
+			q.Months = append(q.Months, time.Month(slices.Index(allowedMonths, m)+1))
+		}
+	}
+
+	return q, nil
+}
+
+// writeBooksJSON writes the books as an indented JSON array while they are read, so exporting a large database does
+// not hold it in memory.
+func writeBooksJSON(w io.Writer, books iter.Seq2[models.ComicBook, error]) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("[")
+
+	n := 0
+	for cb, err := range books {
+		if err != nil {
+			return err
+		}
+
+		b, err := json.MarshalIndent(cb, "  ", "  ")
+		if err != nil {
+			return err
+		}
+
+		if n > 0 {
+			bw.WriteString(",")
+		}
+
+		bw.WriteString("\n  ")
+		bw.Write(b)
+		n++
+	}
+
+	if n > 0 {
+		bw.WriteString("\n")
+	}
+
+	bw.WriteString("]\n")
+	return bw.Flush()
+}
+
+func writeBooksCSV(w io.Writer, books iter.Seq2[models.ComicBook, error], header bool) error {
+	cw := csv.NewWriter(w)
+
+	if header {
+		err := cw.Write([]string{"title", "issue", "publisher", "release_date", "format", "price", "pages", "creators",
+			"url"})
+		if err != nil {
+			return err
+		}
+	}
+
+	for cb, err := range books {
+		if err != nil {
+			return err
+		}
+
+		var release string
+		if !cb.ReleaseDate.IsZero() {
+			release = cb.ReleaseDate.Format(dateLayout)
+		}
+
+		err := cw.Write([]string{cb.Title, cb.Issue, cb.Publisher, release, cb.Format, cb.Price, cb.Pages,
+			formatCredits(cb.Creators), cb.URL})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeBooksTable writes the books as a plain table, which view prints instead of starting the TUI when it can not
+// take over the terminal.
+func writeBooksTable(w io.Writer, books iter.Seq2[models.ComicBook, error]) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "RELEASE\tTITLE\tPUBLISHER\tFORMAT\tPRICE\tCREATORS\n")
+
+	for cb, err := range books {
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", releaseDate(cb), bookTitle(cb), strings.ToUpper(cb.Publisher),
+			cb.Format, cb.Price, formatCredits(cb.Creators))
+	}
+
+	return tw.Flush()
+}
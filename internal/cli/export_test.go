@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"iter"
+	"testing"
+	"time"
+)
+
+func seq(cbs ...models.ComicBook) iter.Seq2[models.ComicBook, error] {
+	return func(yield func(models.ComicBook, error) bool) {
+		for _, cb := range cbs {
+			if !yield(cb, nil) {
+				return
+			}
+		}
+	}
+}
+
+func Test_writeBooksJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		cbs  []models.ComicBook
+	}{
+		{name: "empty", cbs: []models.ComicBook{}},
+		{
+			name: "books",
+			cbs: []models.ComicBook{
+				{Title: "Saga", Issue: "1", Creators: []models.Creator{{Name: "Fiona Staples", Role: "artist"}}},
+				{Title: "Batman & Robin", Issue: "2"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got bytes.Buffer
+			if err := writeBooksJSON(&got, seq(tt.cbs...)); err != nil {
+				t.Fatalf("writeBooksJSON() error = %v", err)
+			}
+
+			var want bytes.Buffer
+			enc := json.NewEncoder(&want)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(tt.cbs); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+
+			if got.String() != want.String() {
+				t.Errorf("writeBooksJSON() got = %s, want %s", got.String(), want.String())
+			}
+		})
+	}
+}
+
+func Test_writeBooksCSV(t *testing.T) {
+	cb := models.ComicBook{
+		Title:       "Saga",
+		Issue:       "1",
+		Publisher:   "image",
+		ReleaseDate: time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC),
+		Price:       "$3.99",
+		Creators: []models.Creator{
+			{Name: "Brian K. Vaughan", Role: "writer"},
+			{Name: "Fiona Staples", Role: "artist"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeBooksCSV(&buf, seq(cb), true); err != nil {
+		t.Fatalf("writeBooksCSV() error = %v", err)
+	}
+
+	want := "title,issue,publisher,release_date,format,price,pages,creators,url\n" +
+		"Saga,1,image,2026-03-18,,$3.99,,\"Brian K. Vaughan (writer), Fiona Staples (artist)\",\n"
+	if got := buf.String(); got != want {
+		t.Errorf("writeBooksCSV() got = %q, want %q", got, want)
+	}
+}
+
+func Test_writeBooksTable(t *testing.T) {
+	cbs := []models.ComicBook{
+		{
+			Title:       "Saga",
+			Issue:       "1",
+			Publisher:   "image",
+			Format:      "singles",
+			Price:       "$3.99",
+			ReleaseDate: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+			Creators:    []models.Creator{{Name: "Fiona Staples", Role: "artist"}},
+		},
+		{Title: "Batman Vol. 1", Publisher: "dc", Format: "trades"},
+	}
+
+	var got bytes.Buffer
+	if err := writeBooksTable(&got, seq(cbs...)); err != nil {
+		t.Fatalf("writeBooksTable() error = %v", err)
+	}
+
+	want := "RELEASE     TITLE          PUBLISHER  FORMAT   PRICE  CREATORS\n" +
+		"2026-03-04  Saga #1        IMAGE      singles  $3.99  Fiona Staples (artist)\n" +
+		"-           Batman Vol. 1  DC         trades          \n"
+	if got.String() != want {
+		t.Errorf("writeBooksTable() = %q, want %q", got.String(), want)
+	}
+}
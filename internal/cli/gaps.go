@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/MikkelvtK/solipull/internal/service"
+	"github.com/urfave/cli/v3"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+func (c *CLI) collectionGaps() *cli.Command {
+	return &cli.Command{
+		Name:  "gaps",
+		Usage: "Find missing issues of the series you collect.",
+		Description: "For every series with a book in the collection, lists the released issues you do not own and " +
+			"the solicited issues that are not on your pull list yet. Run a sync first so all issues are known.",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			publisher := strings.ToLower(cmd.String("publisher"))
+			if publisher != "" {
+				if _, err := parseStringSliceFlag("publisher", []string{publisher}, c.solService.Publishers()); err != nil {
+					return err
+				}
+			}
+
+			gaps, err := c.collectionService.Gaps(ctx, publisher, time.Now())
+			if err != nil {
+				return err
+			}
+
+			return writeGaps(os.Stdout, gaps)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "publisher",
+				Aliases: []string{"p"},
+				Usage:   "Only check series of this publisher",
+			},
+		},
+	}
+}
+
+func writeGaps(w io.Writer, gaps []service.SeriesGaps) error {
+	if len(gaps) == 0 {
+		_, err := fmt.Fprintln(w, "✔ No gaps in your collection.")
+		return err
+	}
+
+	for _, g := range gaps {
+		fmt.Fprintf(w, "%s (%s)\n", seriesName(g.Series), strings.ToUpper(g.Series.Publisher))
+
+		if len(g.Missing) > 0 {
+			fmt.Fprintf(w, "  Missing:  #%s\n", issueRanges(g.Missing))
+		}
+
+		for _, is := range g.Upcoming {
+			when := "on " + releaseDate(is.ComicBook)
+			if is.ReleaseDate.IsZero() {
+				when = "without a release date"
+			}
+
+			fmt.Fprintf(w, "  Upcoming: #%s %s, not on your pull list\n", issueLabel(is), when)
+		}
+
+		fmt.Fprintln(w)
+	}
+
+	return nil
+}
+
+func seriesName(s models.Series) string {
+	name := s.Title
+	if s.Volume > 0 {
+		name += fmt.Sprintf(" Vol. %d", s.Volume)
+	}
+	if s.StartYear > 0 {
+		name += fmt.Sprintf(" (%d)", s.StartYear)
+	}
+	if s.Kind != models.SeriesOngoing {
+		name += " " + s.Kind
+	}
+
+	return name
+}
+
+// issueRanges collapses runs of consecutive whole issue numbers, so 1, 2, 3, 5 becomes "1-3, 5".
+func issueRanges(issues []models.SeriesIssue) string {
+	var parts []string
+
+	for i := 0; i < len(issues); i++ {
+		j := i
+		for j+1 < len(issues) && isWhole(issues[j].Number.Number) &&
+			issues[j+1].Number.Number == issues[j].Number.Number+1 {
+			j++
+		}
+
+		if j > i+1 {
+			parts = append(parts, fmt.Sprintf("%s-%s", issueLabel(issues[i]), issueLabel(issues[j])))
+			i = j
+			continue
+		}
+
+		parts = append(parts, issueLabel(issues[i]))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func isWhole(n float64) bool {
+	return n == float64(int64(n))
+}
+
+// issueLabel is the number of an issue without its suffix, gaps are found per number.
+func issueLabel(is models.SeriesIssue) string {
+	return models.IssueNumber{Number: is.Number.Number, Numeric: true}.String()
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"github.com/MikkelvtK/solipull/internal/models"
+	"testing"
+)
+
+func Test_issueRanges(t *testing.T) {
+	issues := func(numbers ...string) []models.SeriesIssue {
+		var s []models.SeriesIssue
+		for _, n := range numbers {
+			s = append(s, models.SeriesIssue{Number: models.ParseIssue(n)})
+		}
+
+		return s
+	}
+
+	tests := []struct {
+		name   string
+		issues []models.SeriesIssue
+		want   string
+	}{
+		{
+			name:   "single",
+			issues: issues("4"),
+			want:   "4",
+		},
+		{
+			name:   "collapses runs",
+			issues: issues("1", "2", "3", "5", "7", "8"),
+			want:   "1-3, 5, 7, 8",
+		},
+		{
+			name:   "drops suffixes and keeps fractions",
+			issues: issues("½", "1.MU", "2", "3"),
+			want:   "½, 1-3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := issueRanges(tt.issues); got != tt.want {
+				t.Errorf("issueRanges() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/importer"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/MikkelvtK/solipull/internal/service"
+	"github.com/urfave/cli/v3"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+func (c *CLI) collectionImport() *cli.Command {
+	return &cli.Command{
+		Name:      "import",
+		Usage:     "Import a collection or pull list exported from another tool.",
+		ArgsUsage: "<file.csv>",
+		Description: "Reads a CSV export of League of Comic Geeks (locg) or CLZ Comics (clz), or any CSV with " +
+			"--format generic and --map field=column for every column that is not named after its field. Rows are " +
+			"matched against the stored books by title, issue and publisher, so sync the months you are importing " +
+			"first. Rows that do not match a single book or hold a value that can not be read are listed. Fields: " + strings.Join(importer.Fields, ", ") + ".",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.NArg() != 1 {
+				return errors.New("expected a csv file")
+			}
+
+			format, err := importFormat(cmd.String("format"), cmd.StringSlice("map"), cmd.String("status"))
+			if err != nil {
+				return err
+			}
+
+			f, err := os.Open(cmd.Args().First())
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			rows, err := importer.Read(f, format)
+			if err != nil {
+				return err
+			}
+
+			res, err := c.collectionService.Import(ctx, rows)
+			if err != nil {
+				return err
+			}
+
+			return writeImportResult(os.Stdout, res, len(rows))
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"f"},
+				Value:   importer.LeagueOfComicGeeks.Name,
+				Usage:   "Format of the file: locg, clz or generic",
+			},
+			&cli.StringSliceFlag{
+				Name:  "map",
+				Usage: "Column of a field in a generic file, like title=\"Series Name\"",
+			},
+			&cli.StringFlag{
+				Name:    "status",
+				Aliases: []string{"s"},
+				Usage:   "Status of rows without one, defaults to wanted for locg and owned otherwise",
+			},
+		},
+	}
+}
+
+func importFormat(name string, mappings []string, status string) (importer.Format, error) {
+	status = strings.ToLower(status)
+
+	if name == "generic" {
+		if status == "" {
+			status = models.StatusOwned
+		}
+
+		return importer.Generic(mappings, status)
+	}
+
+	f, ok := importer.Formats[name]
+	if !ok {
+		return f, fmt.Errorf("unknown format %q, expected locg, clz or generic", name)
+	}
+
+	if len(mappings) > 0 {
+		return f, errors.New("--map is only supported with --format generic")
+	}
+
+	if status != "" {
+		f.Status = status
+	}
+
+	return f, nil
+}
+
+func writeImportResult(w io.Writer, res service.ImportResult, rows int) error {
+	fmt.Fprintf(w, "✔ Imported %d of %d rows.\n", res.Imported, rows)
+
+	if len(res.Unmatched) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(w, "\n⚠ %d rows could not be imported:\n\n", len(res.Unmatched))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "LINE\tTITLE\tISSUE\tPUBLISHER\tREASON\n")
+
+	for _, u := range res.Unmatched {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", u.Row.Line, u.Row.Title, u.Row.Issue, u.Row.Publisher, u.Reason)
+	}
+
+	return tw.Flush()
+}
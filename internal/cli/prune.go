@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/urfave/cli/v3"
+	"io"
+	"os"
+	"time"
+)
+
+func (c *CLI) dbPrune() *cli.Command {
+	return &cli.Command{
+		Name:  "prune",
+		Usage: "Delete books released a long time ago.",
+		Description: "Deletes the books released longer ago than --older-than, or than the retention in the config, " +
+			"together with their credits. Books in your collection or on your pull list are kept.",
+		Before: c.requireDatabase,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			r := c.dbService.Retention()
+			if s := cmd.String("older-than"); s != "" {
+				var err error
+				if r, err = models.ParseRetention(s); err != nil {
+					return err
+				}
+			}
+
+			if r.IsZero() {
+				return errors.New("no retention given, use --older-than or set retention in the config")
+			}
+
+			now := time.Now()
+
+			summary, err := c.dbService.Prune(ctx, r, now, true)
+			if err != nil {
+				return err
+			}
+
+			if err := writePruneSummary(os.Stdout, summary); err != nil {
+				return err
+			}
+
+			if cmd.Bool("dry-run") || summary.Books == 0 {
+				return nil
+			}
+
+			ok, err := confirm(cmd, fmt.Sprintf("Delete %d books?", summary.Books))
+			if err != nil || !ok {
+				return err
+			}
+
+			summary, err = c.dbService.Prune(ctx, r, now, false)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("✔ Deleted %d books\n", summary.Books)
+			return nil
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "older-than",
+				Usage: "Delete books released longer ago than this, like 90d, 8w, 24mo or 2y",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Only show what would be deleted",
+			},
+		},
+	}
+}
+
+// applyRetention prunes the books outside the retention policy from the config after a sync.
+func (c *CLI) applyRetention(ctx context.Context) error {
+	if c.dbService == nil {
+		return nil
+	}
+
+	summary, err := c.dbService.ApplyRetention(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if summary.Books == 0 {
+		return nil
+	}
+
+	fmt.Printf("\n✔ Deleted %d books released before %s, as set by the retention in the config\n", summary.Books,
+		summary.Before.Format(dateLayout))
+	return nil
+}
+
+func writePruneSummary(w io.Writer, s models.PruneSummary) error {
+	fmt.Fprintf(w, "Books released before %s:\n", s.Before.Format(dateLayout))
+	fmt.Fprintf(w, "  %d books with %d credits would be deleted\n", s.Books, s.Creators)
+
+	if s.Series > 0 {
+		fmt.Fprintf(w, "  %d series without books left would be deleted\n", s.Series)
+	}
+
+	_, err := fmt.Fprintf(w, "  %d books are kept as they are in your collection or on your pull list\n", s.Kept)
+	return err
+}
@@ -6,8 +6,9 @@ import (
 
 func (c *CLI) solicitation() *cli.Command {
 	return &cli.Command{
-		Name:  "solicitation",
-		Usage: "Solicitation tool",
+		Name:   "solicitation",
+		Usage:  "Solicitation tool",
+		Before: c.requireDatabase,
 		Commands: []*cli.Command{
 			c.sync(),
 			c.view(),
@@ -2,38 +2,80 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/MikkelvtK/solipull/internal/service"
 	"github.com/schollz/progressbar/v3"
 	"github.com/urfave/cli/v3"
+	"io"
 	"log/slog"
 	"os"
+	"time"
 )
 
 func (c *CLI) sync() *cli.Command {
 	return &cli.Command{
 		Name:  "sync",
 		Usage: "Synchronize local database with the latest comic book publisher solicitations.",
-		Description: "Scrapes Comic Releases sitemap and solicitation pages to identify new comic book releases. " +
+		Description: "Scrapes the solicitation pages of a source, by default the one providing the publishers, to " +
+			"identify new comic book releases. " +
 			"Discovered titles are parsed for data and inserted into the local SQLite database. This process ensures " +
 			"your available titles are up to date for collection and pull-list management.",
+		Commands: []*cli.Command{
+			c.syncURL(),
+			c.syncReport(),
+		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
-			publishers, err := getPublishersUserInput(cmd)
-			if err != nil {
-				return err
+			if cmd.Bool("tui") && !interactive(cmd) {
+				return errors.New("the sync dashboard needs a terminal, leave out --tui to follow the sync")
 			}
 
-			months, err := getMonthsUserInput(cmd)
-			if err != nil {
-				return err
+			started := time.Now()
+			source := cmd.String("source")
+
+			run := func(ctx context.Context, obs service.ScrapingObserver) error {
+				return c.solService.Resume(ctx, obs, source)
+			}
+
+			if !cmd.Bool("resume") {
+				publishers, err := c.getPublishersUserInput(cmd)
+				if err != nil {
+					return err
+				}
+
+				months, err := c.getMonthsUserInput(cmd)
+				if err != nil {
+					return err
+				}
+
+				if cmd.Bool("dry-run") {
+					return c.dryRun(ctx, cmd, months, publishers)
+				}
+
+				run = func(ctx context.Context, obs service.ScrapingObserver) error {
+					return c.solService.Sync(ctx, obs, source, months, publishers)
+				}
+			}
+
+			if cmd.Bool("tui") {
+				if err := c.runDashboard(ctx, source, run); err != nil {
+					return syncError(err)
+				}
+
+				return c.afterSync(ctx, started)
 			}
 
 			rep := newSyncReporter(c.metrics, c.logger)
-			if err = c.solService.Sync(ctx, rep, months, publishers); err != nil {
+			if err := run(ctx, rep); err != nil {
+				return syncError(err)
+			}
+
+			if err := rep.reportResults(); err != nil {
 				return err
 			}
 
-			return rep.reportResults()
+			return c.afterSync(ctx, started)
 		},
 		Flags: []cli.Flag{
 			&cli.StringSliceFlag{
@@ -46,12 +88,42 @@ func (c *CLI) sync() *cli.Command {
 				Aliases: []string{"m"},
 				Usage:   "Months to sync",
 			},
+			&cli.StringFlag{
+				Name:    "source",
+				Aliases: []string{"s"},
+				Usage:   "Source to sync from, defaults to the one providing the publishers",
+			},
+			&cli.BoolFlag{
+				Name:  "resume",
+				Usage: "Continue an interrupted sync",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show what a sync would change without saving anything",
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "Output the dry run as JSON",
+			},
+			&cli.BoolFlag{
+				Name:  "tui",
+				Usage: "Follow the sync on a live dashboard instead of a progress bar",
+			},
 		},
 	}
 }
 
+func syncError(err error) error {
+	if errors.Is(err, context.Canceled) {
+		return errors.New("sync interrupted, run 'solipull solicitation sync --resume' to continue")
+	}
+
+	return err
+}
+
 type syncReporter struct {
-	pb *progressbar.ProgressBar
+	pb  *progressbar.ProgressBar
+	out io.Writer
 
 	metrics *models.AppMetrics
 	logger  *slog.Logger
@@ -63,7 +135,7 @@ func (s *syncReporter) OnError(ctx context.Context, level slog.Level, msg string
 }
 
 func (s *syncReporter) OnStart() {
-	fmt.Println("➔ Finding solicitation pages to scrape...")
+	fmt.Fprintln(s.writer(), "➔ Finding solicitation pages to scrape...")
 }
 
 func (s *syncReporter) OnUrlFound(n int) {
@@ -72,15 +144,15 @@ func (s *syncReporter) OnUrlFound(n int) {
 
 func (s *syncReporter) OnNavigationComplete() {
 	if s.metrics.PagesFound.Load() == 0 {
-		fmt.Println("✗ No pages found")
+		fmt.Fprintln(s.writer(), "✗ No pages found")
 		return
 	}
 
-	fmt.Printf("✔ Found: %d pages to scrape\n\n", s.metrics.PagesFound.Load())
+	fmt.Fprintf(s.writer(), "✔ Found: %d pages to scrape\n\n", s.metrics.PagesFound.Load())
 
 	s.pb = progressbar.NewOptions(int(s.metrics.PagesFound.Load()),
 		progressbar.OptionSetDescription("➔ Pages scraped:"),
-		progressbar.OptionSetWriter(os.Stdout),
+		progressbar.OptionSetWriter(s.writer()),
 		progressbar.OptionShowCount(),
 		progressbar.OptionShowElapsedTimeOnFinish(),
 		progressbar.OptionSetRenderBlankState(true),
@@ -95,11 +167,26 @@ func (s *syncReporter) OnNavigationComplete() {
 	)
 }
 
-func (s *syncReporter) reportResults() error {
+// writer returns where progress is reported, stdout unless configured otherwise.
+func (s *syncReporter) writer() io.Writer {
+	if s.out == nil {
+		return os.Stdout
+	}
+
+	return s.out
+}
+
+func (s *syncReporter) finish() error {
 	if s.pb != nil {
-		if err := s.pb.Finish(); err != nil {
-			return err
-		}
+		return s.pb.Finish()
+	}
+
+	return nil
+}
+
+func (s *syncReporter) reportResults() error {
+	if err := s.finish(); err != nil {
+		return err
 	}
 
 	fmt.Println("✅  Sync complete!")
@@ -134,3 +221,12 @@ func newSyncReporter(metrics *models.AppMetrics, logger *slog.Logger) *syncRepor
 		logger:  logger,
 	}
 }
+
+// afterSync reports the new books from followed creators and applies the retention policy.
+func (c *CLI) afterSync(ctx context.Context, since time.Time) error {
+	if err := c.reportFollowed(ctx, since); err != nil {
+		return err
+	}
+
+	return c.applyRetention(ctx)
+}
@@ -0,0 +1,392 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/MikkelvtK/solipull/internal/service"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// dashboardPages is the number of in flight and of finished pages the dashboard lists.
+const dashboardPages = 5
+
+var (
+	warningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD700"))
+	failedStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5F5F"))
+	doneStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#5FD75F"))
+)
+
+// runDashboard runs the sync behind a live dashboard instead of the progress bar. Quitting the dashboard cancels the
+// sync, which stops like an interrupted sync and can be resumed.
+func (c *CLI) runDashboard(ctx context.Context, source string,
+	run func(ctx context.Context, obs service.ScrapingObserver) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	p := tea.NewProgram(newDashboard(source, time.Now(), cancel))
+
+	errCh := make(chan error, 1)
+	go func() {
+		err := run(ctx, &dashboardObserver{p: p, metrics: c.metrics})
+		p.Send(syncDoneMsg{err: err})
+		errCh <- err
+	}()
+
+	if _, err := p.Run(); err != nil {
+		cancel()
+		<-errCh
+		return err
+	}
+
+	return <-errCh
+}
+
+type (
+	syncStartMsg    struct{}
+	urlFoundMsg     int
+	navDoneMsg      struct{}
+	bookScrapedMsg  int
+	pageCompleteMsg struct{}
+	warningMsg      string
+	tickMsg         time.Time
+	syncDoneMsg     struct{ err error }
+)
+
+type pageStatus int
+
+const (
+	pageInFlight pageStatus = iota
+	pageDone
+	pageFailed
+)
+
+// pageMsg reports a page that started, finished or failed.
+type pageMsg struct {
+	url    string
+	status pageStatus
+	books  int
+	err    error
+}
+
+// dashboardObserver passes the progress of the sync on to the dashboard and keeps the metrics like syncReporter.
+type dashboardObserver struct {
+	p       *tea.Program
+	metrics *models.AppMetrics
+}
+
+func (o *dashboardObserver) OnError(_ context.Context, level slog.Level, msg string, args ...any) {
+	o.metrics.ErrorsFound.Add(1)
+	o.p.Send(warningMsg(formatWarning(level, msg, args...)))
+}
+
+func (o *dashboardObserver) OnStart() {
+	o.p.Send(syncStartMsg{})
+}
+
+func (o *dashboardObserver) OnUrlFound(n int) {
+	o.metrics.PagesFound.Add(int32(n))
+	o.p.Send(urlFoundMsg(n))
+}
+
+func (o *dashboardObserver) OnNavigationComplete() {
+	o.p.Send(navDoneMsg{})
+}
+
+func (o *dashboardObserver) OnComicBookScraped(n int) {
+	o.metrics.ComicBooksFound.Add(int32(n))
+	o.p.Send(bookScrapedMsg(n))
+}
+
+func (o *dashboardObserver) OnScrapingComplete() {
+	o.p.Send(pageCompleteMsg{})
+}
+
+func (o *dashboardObserver) OnPageStart(url string) {
+	o.p.Send(pageMsg{url: url, status: pageInFlight})
+}
+
+func (o *dashboardObserver) OnPageScraped(url string, books int) {
+	o.p.Send(pageMsg{url: url, status: pageDone, books: books})
+}
+
+func (o *dashboardObserver) OnPageFailed(url string, err error) {
+	o.p.Send(pageMsg{url: url, status: pageFailed, err: err})
+}
+
+// formatWarning writes a warning like a log line: the level and message followed by the arguments as key=value.
+func formatWarning(level slog.Level, msg string, args ...any) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s", level, msg)
+
+	for i := 0; i+1 < len(args); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", args[i], args[i+1])
+	}
+
+	return b.String()
+}
+
+type dashboardPage struct {
+	url    string
+	status pageStatus
+	books  int
+	err    error
+}
+
+// dashboard shows the pages of a running sync, the books found on them and the warnings raised while scraping.
+type dashboard struct {
+	source  string
+	started time.Time
+	now     time.Time
+	cancel  context.CancelFunc
+
+	navigating bool
+	found      int
+	done       int
+	books      int
+	pages      []dashboardPage
+	index      map[string]int
+
+	warnings []string
+	viewport viewport.Model
+
+	cancelling bool
+	finished   bool
+	err        error
+	width      int
+	height     int
+}
+
+func newDashboard(source string, started time.Time, cancel context.CancelFunc) dashboard {
+	if source == "" {
+		source = "Comic Releases"
+	}
+
+	return dashboard{
+		source:   source,
+		started:  started,
+		now:      started,
+		cancel:   cancel,
+		index:    make(map[string]int),
+		viewport: viewport.New(80, 8),
+		width:    80,
+	}
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return tickMsg(t) })
+}
+
+func (d dashboard) Init() tea.Cmd {
+	return tick()
+}
+
+func (d dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			if d.finished || d.cancelling {
+				return d, tea.Quit
+			}
+
+			d.cancelling = true
+			d.cancel()
+			return d, nil
+		}
+	case tea.WindowSizeMsg:
+		d.width, d.height = msg.Width, msg.Height
+		d.viewport.Width = msg.Width
+		d.resizeWarnings()
+		return d, nil
+	case tickMsg:
+		d.now = time.Time(msg)
+		if d.finished {
+			return d, nil
+		}
+		return d, tick()
+	case syncStartMsg:
+		d.navigating = true
+	case urlFoundMsg:
+		d.found += int(msg)
+	case navDoneMsg:
+		d.navigating = false
+	case bookScrapedMsg:
+		d.books += int(msg)
+	case pageCompleteMsg:
+		d.done++
+	case pageMsg:
+		d.updatePage(msg)
+	case warningMsg:
+		bottom := d.viewport.AtBottom()
+		d.warnings = append(d.warnings, string(msg))
+		d.viewport.SetContent(strings.Join(d.warnings, "\n"))
+		if bottom {
+			d.viewport.GotoBottom()
+		}
+	case syncDoneMsg:
+		d.finished, d.err = true, msg.err
+		d.now = time.Now()
+		return d, tea.Quit
+	}
+
+	d.resizeWarnings()
+
+	var cmd tea.Cmd
+	d.viewport, cmd = d.viewport.Update(msg)
+	return d, cmd
+}
+
+func (d *dashboard) updatePage(msg pageMsg) {
+	p := dashboardPage{url: msg.url, status: msg.status, books: msg.books, err: msg.err}
+
+	if i, ok := d.index[msg.url]; ok {
+		d.pages[i] = p
+		return
+	}
+
+	d.index[msg.url] = len(d.pages)
+	d.pages = append(d.pages, p)
+}
+
+// resizeWarnings gives the warnings the height that is left below the pages.
+func (d *dashboard) resizeWarnings() {
+	if d.height == 0 {
+		return
+	}
+
+	d.viewport.Height = max(d.height-lipgloss.Height(d.top())-3, 3)
+}
+
+// count returns the number of pages with the status.
+func (d dashboard) count(status pageStatus) int {
+	n := 0
+	for _, p := range d.pages {
+		if p.status == status {
+			n++
+		}
+	}
+
+	return n
+}
+
+// eta estimates the time left from the average time a page took so far. It is unknown until the pages to scrape
+// are found and the first one is done.
+func (d dashboard) eta() (time.Duration, bool) {
+	if d.navigating || d.done == 0 {
+		return 0, false
+	}
+
+	left := d.found - d.done - d.count(pageFailed)
+	if left <= 0 {
+		return 0, true
+	}
+
+	return d.now.Sub(d.started) / time.Duration(d.done) * time.Duration(left), true
+}
+
+func (d dashboard) status() string {
+	switch {
+	case d.finished && d.err != nil:
+		return failedStyle.Render("✗ Sync stopped: " + d.err.Error())
+	case d.finished:
+		return doneStyle.Render("✔ Sync complete")
+	case d.cancelling:
+		return warningStyle.Render("Cancelling, waiting for the pages in flight...")
+	case d.navigating:
+		return "➔ Finding solicitation pages to scrape..."
+	default:
+		return "➔ Scraping pages"
+	}
+}
+
+// progressBar draws the share of the found pages that are done or failed.
+func (d dashboard) progressBar(width int) string {
+	finished := d.done + d.count(pageFailed)
+
+	filled := 0
+	if d.found > 0 {
+		filled = min(finished*width/d.found, width)
+	}
+
+	return "[" + strings.Repeat("=", filled) + strings.Repeat("-", width-filled) + "]" +
+		fmt.Sprintf(" %d/%d pages", finished, d.found)
+}
+
+// top renders everything above the warnings.
+func (d dashboard) top() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s %s\n", labelStyle.Render("Syncing "+d.source), d.status())
+
+	elapsed := d.now.Sub(d.started).Round(time.Second)
+	eta := "  ETA unknown"
+	if left, ok := d.eta(); ok {
+		eta = "  ETA " + left.Round(time.Second).String()
+	}
+	if d.finished {
+		eta = ""
+	}
+	fmt.Fprintf(&b, "%s  elapsed %s%s\n\n", d.progressBar(min(max(d.width-50, 10), 40)), elapsed, eta)
+
+	inFlight, failed := d.count(pageInFlight), d.count(pageFailed)
+	queued := max(d.found-d.done-failed-inFlight, 0)
+	fmt.Fprintf(&b, "Queued %d • In flight %d • Done %d • Failed %d • Books %d • Warnings %d\n\n",
+		queued, inFlight, d.done, failed, d.books, len(d.warnings))
+
+	b.WriteString(labelStyle.Render("In flight") + "\n")
+	b.WriteString(d.pageLines(func(p dashboardPage) bool { return p.status == pageInFlight }))
+
+	b.WriteString("\n" + labelStyle.Render("Recent pages") + "\n")
+	b.WriteString(d.pageLines(func(p dashboardPage) bool { return p.status != pageInFlight }))
+
+	return b.String()
+}
+
+// pageLines lists the last pages that match.
+func (d dashboard) pageLines(match func(dashboardPage) bool) string {
+	var lines []string
+	for i := len(d.pages) - 1; i >= 0 && len(lines) < dashboardPages; i-- {
+		if p := d.pages[i]; match(p) {
+			lines = append(lines, d.pageLine(p))
+		}
+	}
+
+	if len(lines) == 0 {
+		return mutedStyle.Render("  none") + "\n"
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func (d dashboard) pageLine(p dashboardPage) string {
+	line := "  ⟳ " + p.url
+	switch p.status {
+	case pageDone:
+		line = doneStyle.Render("  ✔ ") + fmt.Sprintf("%s  %d books", p.url, p.books)
+	case pageFailed:
+		line = failedStyle.Render("  ✗ ") + fmt.Sprintf("%s  %v", p.url, p.err)
+	}
+
+	return lipgloss.NewStyle().MaxWidth(d.width).Render(line)
+}
+
+func (d dashboard) View() string {
+	help := "q cancel • ↑/↓ scroll warnings"
+	if d.finished {
+		help = ""
+	}
+
+	warnings := d.viewport.View()
+	if len(d.warnings) == 0 {
+		warnings = mutedStyle.Render("  none")
+	}
+
+	return d.top() + "\n" + labelStyle.Render("Warnings") + "\n" + warningStyle.Render(warnings) + "\n" +
+		mutedStyle.Render(help) + "\n"
+}
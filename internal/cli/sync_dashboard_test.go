@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"errors"
+	tea "github.com/charmbracelet/bubbletea"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_formatWarning(t *testing.T) {
+	got := formatWarning(slog.LevelWarn, "no title found", "url", "https://example.com", "dangling")
+	if want := "WARN no title found url=https://example.com"; got != want {
+		t.Errorf("formatWarning() = %q, want %q", got, want)
+	}
+}
+
+func TestDashboard_Update(t *testing.T) {
+	started := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	cancelled := false
+
+	var d tea.Model = newDashboard("", started, func() { cancelled = true })
+	send := func(msgs ...tea.Msg) {
+		for _, msg := range msgs {
+			d, _ = d.Update(msg)
+		}
+	}
+
+	send(
+		syncStartMsg{},
+		urlFoundMsg(4),
+		navDoneMsg{},
+		pageMsg{url: "https://example.com/dc", status: pageInFlight},
+		pageMsg{url: "https://example.com/image", status: pageInFlight},
+		pageMsg{url: "https://example.com/marvel", status: pageInFlight},
+		bookScrapedMsg(12),
+		pageMsg{url: "https://example.com/dc", status: pageDone, books: 12},
+		pageCompleteMsg{},
+		pageMsg{url: "https://example.com/marvel", status: pageFailed, err: errors.New("Not Found")},
+		warningMsg("WARN no release date found"),
+		tickMsg(started.Add(30*time.Second)),
+	)
+
+	got := d.(dashboard)
+	view := got.View()
+	for _, want := range []string{
+		"Syncing Comic Releases",
+		"Queued 1 • In flight 1 • Done 1 • Failed 1 • Books 12 • Warnings 1",
+		"⟳ https://example.com/image",
+		"https://example.com/dc  12 books",
+		"https://example.com/marvel  Not Found",
+		"WARN no release date found",
+		"2/4 pages",
+		"elapsed 30s",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() = %q, want it to contain %q", view, want)
+		}
+	}
+
+	if eta, ok := got.eta(); !ok || eta != time.Minute {
+		t.Errorf("eta() = %v, %v, want 1m0s", eta, ok)
+	}
+
+	_, cmd := d.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	if !cancelled || cmd != nil {
+		t.Errorf("ctrl+c cancelled = %v, want the sync cancelled without quitting", cancelled)
+	}
+
+	d, cmd = d.Update(syncDoneMsg{err: errors.New("context canceled")})
+	if cmd == nil || !strings.Contains(d.View(), "Sync stopped: context canceled") {
+		t.Errorf("after the sync stopped View() = %q, want it to quit with the error", d.View())
+	}
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/urfave/cli/v3"
+	"io"
+	"os"
+	"text/tabwriter"
+)
+
+func (c *CLI) syncReport() *cli.Command {
+	return &cli.Command{
+		Name:  "report",
+		Usage: "Show the extraction quality of the last sync.",
+		Description: "Lists every page visited by the last sync with the share of books on it that have a title, " +
+			"page count, price, creators and release date. Pages without books or where a field falls below the " +
+			"threshold are marked, which usually means the layout of the page has changed.",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			run, err := c.solService.LastRun(ctx, cmd.String("source"))
+			if errors.Is(err, models.ErrNoSyncRun) {
+				return errors.New("no sync has been run yet")
+			}
+			if err != nil {
+				return err
+			}
+
+			return writeQualityReport(os.Stdout, run, cmd.Float("threshold"))
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "source",
+				Aliases: []string{"s"},
+				Usage:   "Only report on syncs of this source",
+			},
+			&cli.FloatFlag{
+				Name:  "threshold",
+				Value: 0.8,
+				Usage: "Fill rate below which a page is marked as degraded",
+			},
+		},
+	}
+}
+
+func writeQualityReport(w io.Writer, run models.SyncRun, threshold float64) error {
+	fmt.Fprintf(w, "Sync of %s on %s (%d pages)\n\n", run.Source, run.StartedAt.Format("2006-01-02 15:04"),
+		len(run.Pages))
+
+	if len(run.Pages) == 0 {
+		_, err := fmt.Fprintln(w, "⚠ No solicitation pages were found, the layout of the start page may have changed.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "\tPAGE\tBOOKS\tTITLE\tPAGES\tPRICE\tCREATORS\tRELEASE\n")
+
+	degraded := 0
+	for _, p := range run.Pages {
+		mark := ""
+		if p.Degraded(threshold) {
+			mark = "⚠"
+			degraded++
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\n", mark, p.URL, p.Books,
+			fillRate(p, p.Dropped), fillRate(p, p.MissingPages), fillRate(p, p.MissingPrice),
+			fillRate(p, p.MissingCreators), fillRate(p, p.MissingReleaseDate))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if degraded == 0 {
+		_, err := fmt.Fprintln(w, "\n✔ No pages with degraded extraction.")
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\n⚠ %d of %d pages have a field below %.0f%%.\n", degraded, len(run.Pages),
+		threshold*100)
+	return err
+}
+
+func fillRate(p models.PageQuality, missing int) string {
+	return fmt.Sprintf("%.0f%%", p.FillRate(missing)*100)
+}
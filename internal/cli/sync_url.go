@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/urfave/cli/v3"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+func (c *CLI) syncURL() *cli.Command {
+	return &cli.Command{
+		Name:      "url",
+		Usage:     "Scrape one or more solicitation pages directly.",
+		ArgsUsage: "<url...>",
+		Description: "Scrapes the given solicitation pages without going through the sitemap and prints the parsed " +
+			"books. Useful for picking up a late addendum to a month or for debugging an extraction problem.",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			urls := cmd.Args().Slice()
+			if len(urls) == 0 {
+				return errors.New("no urls provided")
+			}
+
+			publisher := strings.ToLower(cmd.String("publisher"))
+			if publisher != "" {
+				if _, err := parseStringSliceFlag("publisher", []string{publisher}, c.solService.Publishers()); err != nil {
+					return err
+				}
+			}
+
+			rep := newSyncReporter(c.metrics, c.logger)
+			rep.out = os.Stderr
+
+			cbs, err := c.solService.SyncPages(ctx, rep, cmd.String("source"), urls, publisher, !cmd.Bool("no-save"))
+			if err != nil {
+				return syncError(err)
+			}
+
+			if err := rep.finish(); err != nil {
+				return err
+			}
+
+			if cmd.Bool("json") {
+				return writeComicBooksJSON(os.Stdout, cbs)
+			}
+
+			return writeComicBooksTable(os.Stdout, cbs)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "publisher",
+				Aliases: []string{"p"},
+				Usage:   "Publisher of the pages, derived from the url when not set",
+			},
+			&cli.StringFlag{
+				Name:    "source",
+				Aliases: []string{"s"},
+				Usage:   "Source the pages belong to, defaults to the one providing --publisher or Comic Releases",
+			},
+			&cli.BoolFlag{
+				Name:  "no-save",
+				Usage: "Only print the parsed books",
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "Output as JSON",
+			},
+		},
+	}
+}
+
+func writeComicBooksJSON(w io.Writer, cbs []models.ComicBook) error {
+	if cbs == nil {
+		cbs = []models.ComicBook{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(cbs)
+}
+
+func writeComicBooksTable(w io.Writer, cbs []models.ComicBook) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "TITLE\tPUBLISHER\tRELEASE\tFORMAT\tPRICE\tPAGES\tCREATORS\n")
+
+	for _, cb := range cbs {
+		creators := make([]string, 0, len(cb.Creators))
+		for _, cr := range cb.Creators {
+			creators = append(creators, fmt.Sprintf("%s (%s)", cr.Name, cr.Role))
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", bookTitle(cb), strings.ToUpper(cb.Publisher), releaseDate(cb),
+			cb.Format, cb.Price, cb.Pages, strings.Join(creators, ", "))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d books parsed.\n", len(cbs))
+	return err
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/urfave/cli/v3"
+	"os"
 	"slices"
 	"strings"
+	"time"
 )
 
 var docStyle = lipgloss.NewStyle().Margin(1, 2)
@@ -19,15 +22,45 @@ func (c *CLI) view() *cli.Command {
 		Name:  "view",
 		Usage: "View and export comic book solicitations.",
 		Description: "Displays solicitation data in a formatted and interactive table by default. Supports JSON and " +
-			"CSV exports via flags for use in scripts and external tools.",
+			"CSV exports via flags for use in scripts and external tools. Without a terminal, or with --no-input, a " +
+			"plain table is printed instead.",
 		Action: func(ctx context.Context, cmd *cli.Command) error {
+			q, err := c.viewQuery(cmd)
+			if err != nil {
+				return err
+			}
+
+			books := c.solService.View(ctx, q)
+
+			switch {
+			case cmd.Bool("json"):
+				return writeBooksJSON(os.Stdout, books)
+			case cmd.Bool("csv"), cmd.Bool("csv--no-header"):
+				return writeBooksCSV(os.Stdout, books, !cmd.Bool("csv--no-header"))
+			case !interactive(cmd):
+				return writeBooksTable(os.Stdout, books)
+			}
 
-			cbs, err := c.solService.View(ctx, []string{}, []string{})
+			var cbs []models.ComicBook
+			for cb, err := range books {
+				if err != nil {
+					return err
+				}
+
+				cbs = append(cbs, cb)
+			}
+
+			statuses, err := c.collectionStatuses(ctx)
 			if err != nil {
 				return err
 			}
 
-			m := newModel(cbs)
+			var p pullList
+			if c.collectionService != nil {
+				p = c.collectionService
+			}
+
+			m := newModel(ctx, cbs, statuses, q.Sort, p)
 			if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
 				return err
 			}
@@ -44,6 +77,24 @@ func (c *CLI) view() *cli.Command {
 				Aliases: []string{"m"},
 				Usage:   "Months to view",
 			},
+			&cli.StringFlag{
+				Name:    "search",
+				Aliases: []string{"s"},
+				Usage:   "Only show books with a title or creator containing this text",
+			},
+			&cli.StringFlag{
+				Name:  "sort",
+				Usage: "Order books by release, title or publisher",
+				Value: string(models.SortByRelease),
+			},
+			&cli.IntFlag{
+				Name:  "limit",
+				Usage: "Show at most this many books",
+			},
+			&cli.IntFlag{
+				Name:  "offset",
+				Usage: "Skip this many books, to page through them with --limit",
+			},
 			&cli.BoolFlag{
 				Name:  "json",
 				Usage: "Output as JSON",
@@ -61,11 +112,22 @@ func (c *CLI) view() *cli.Command {
 }
 
 type comicItem struct {
-	cb *models.ComicBook
+	cb       *models.ComicBook
+	status   string
+	selected bool
 }
 
 func (i comicItem) Title() string {
-	return fmt.Sprintf("%s #%s", i.cb.Title, i.cb.Issue)
+	title := fmt.Sprintf("%s #%s", i.cb.Title, i.cb.Issue)
+	if i.status != "" {
+		title = statusMarker(i.status) + " " + title
+	}
+
+	if i.selected {
+		title = "▸ " + title
+	}
+
+	return title
 }
 
 func (i comicItem) Description() string {
@@ -79,7 +141,15 @@ func (i comicItem) Description() string {
 		}
 	})
 
-	return fmt.Sprintf("[%s] | %s | %s (%s)", pub, dt, i.cb.Price, strings.Join(names, ", "))
+	desc := fmt.Sprintf("[%s] | %s | %s (%s)", pub, dt, i.cb.Price, strings.Join(names, ", "))
+	switch item := (models.CollectionItem{Status: i.status}); {
+	case item.Pulled():
+		desc += " | pull list: " + i.status
+	case i.status != "":
+		desc += " | " + i.status
+	}
+
+	return desc
 }
 
 func (i comicItem) FilterValue() string {
@@ -91,18 +161,91 @@ func (i comicItem) FilterValue() string {
 		}
 	})
 
-	s := strings.ToLower(fmt.Sprintf("%s %s %s %s %s",
+	s := strings.ToLower(fmt.Sprintf("%s %s %s %s %s %s",
 		i.cb.Title,
 		i.cb.Issue,
 		i.cb.ReleaseDate.Month(),
 		i.cb.Publisher,
-		strings.Join(c, " ")))
+		strings.Join(c, " "),
+		i.status))
 
 	return s
 }
 
+// viewKeys are the bindings the view adds to the list. They are ignored while typing a filter.
+type viewKeys struct {
+	sortRelease   key.Binding
+	sortTitle     key.Binding
+	sortPublisher key.Binding
+	sortPrice     key.Binding
+	selectItem    key.Binding
+	pull          key.Binding
+	pullSeries    key.Binding
+	calendar      key.Binding
+	nextTab       key.Binding
+	prevTab       key.Binding
+}
+
+func newViewKeys() viewKeys {
+	return viewKeys{
+		sortRelease:   key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "sort by release")),
+		sortTitle:     key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "sort by title")),
+		sortPublisher: key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "sort by publisher")),
+		sortPrice:     key.NewBinding(key.WithKeys("$"), key.WithHelp("$", "sort by price")),
+		selectItem:    key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "select")),
+		pull:          key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "toggle pull list")),
+		pullSeries:    key.NewBinding(key.WithKeys("W"), key.WithHelp("W", "toggle series on pull list")),
+		calendar:      key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "calendar")),
+		nextTab:       key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next tab")),
+		prevTab:       key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "previous tab")),
+	}
+}
+
+func (k viewKeys) bindings() []key.Binding {
+	return []key.Binding{k.sortRelease, k.sortTitle, k.sortPublisher, k.sortPrice, k.selectItem, k.pull, k.pullSeries,
+		k.calendar, k.nextTab, k.prevTab}
+}
+
+func (k viewKeys) shortBindings() []key.Binding {
+	return []key.Binding{k.nextTab, k.selectItem, k.pull, k.calendar}
+}
+
+// sort returns the order bound to the key, if any.
+func (k viewKeys) sort(msg tea.KeyMsg) (models.BookSort, bool) {
+	switch {
+	case key.Matches(msg, k.sortRelease):
+		return models.SortByRelease, true
+	case key.Matches(msg, k.sortTitle):
+		return models.SortByTitle, true
+	case key.Matches(msg, k.sortPublisher):
+		return models.SortByPublisher, true
+	case key.Matches(msg, k.sortPrice):
+		return sortByPrice, true
+	default:
+		return "", false
+	}
+}
+
 type model struct {
-	list list.Model
+	ctx      context.Context
+	list     list.Model
+	books    []models.ComicBook
+	statuses map[string]string
+	// selected holds the collectionKey of every book selected with space.
+	selected map[string]bool
+	pull     pullList
+	sort     models.BookSort
+	keys     viewKeys
+	// day limits the list to the books released on it after picking it in the calendar.
+	day          time.Time
+	cal          calendar
+	showCalendar bool
+	tabs         []viewTab
+	tab          int
+	// cursors holds the index of the selected item on every tab.
+	cursors map[int]int
+	width   int
+	height  int
 }
 
 func (m model) Init() tea.Cmd {
@@ -115,33 +258,180 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.String() == "ctrl+c" {
 			return m, tea.Quit
 		}
+
+		if m.showCalendar {
+			return m, m.updateCalendar(msg)
+		}
+
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+
+		if !m.day.IsZero() && msg.String() == "esc" && m.list.FilterState() == list.Unfiltered {
+			m.showCalendar = true
+			return m, m.showDay(time.Time{})
+		}
+
+		if s, ok := m.keys.sort(msg); ok {
+			m.sort = s
+			m.list.Title = m.title()
+			m.resetCursors()
+			cmd := m.list.SetItems(m.items())
+			m.list.ResetSelected()
+			m.skipHeaders(1)
+			return m, cmd
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.selectItem):
+			return m, m.toggleSelected()
+		case key.Matches(msg, m.keys.pull):
+			return m, m.togglePull(m.targets())
+		case key.Matches(msg, m.keys.pullSeries):
+			return m, m.togglePull(m.series(m.targets()))
+		case key.Matches(msg, m.keys.nextTab):
+			return m, m.switchTab(1)
+		case key.Matches(msg, m.keys.prevTab):
+			return m, m.switchTab(-1)
+		case key.Matches(msg, m.keys.calendar):
+			if ci, ok := m.list.SelectedItem().(comicItem); ok && !ci.cb.ReleaseDate.IsZero() {
+				m.cal.day = dateOf(ci.cb.ReleaseDate)
+			}
+			m.showCalendar = true
+			return m, nil
+		}
+	case pullMsg:
+		return m, m.applyPull(msg)
 	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
 		h, v := docStyle.GetFrameSize()
-		m.list.SetSize(msg.Width-h, msg.Height-v)
+		m.list.SetSize(listWidth(msg.Width-h), msg.Height-v-tabBarHeight)
 	}
 
+	prev := m.list.Index()
+
 	var cmd tea.Cmd
 	m.list, cmd = m.list.Update(msg)
-	return m, cmd
-}
 
-func (m model) View() string {
-	return docStyle.Render(m.list.View())
+	if m.list.Index() < prev {
+		m.skipHeaders(-1)
+	} else {
+		m.skipHeaders(1)
+	}
+
+	return m, cmd
 }
 
-func newModel(cbs []models.ComicBook) *model {
-	items := slices.Collect(func(yield func(list.Item) bool) {
-		for _, cb := range cbs {
-			item := comicItem{cb: &cb}
+// skipHeaders moves the cursor off a week header in the direction it was moving, turning around at either end of
+// the list.
+func (m *model) skipHeaders(dir int) {
+	items := m.list.VisibleItems()
+	i := m.list.Index()
 
-			if !yield(item) {
+	for _, d := range []int{dir, -dir} {
+		j := i
+		for j >= 0 && j < len(items) {
+			if _, ok := items[j].(weekHeader); !ok {
+				if j != i {
+					m.list.Select(j)
+				}
 				return
 			}
+			j += d
 		}
-	})
+	}
+}
+
+func (m model) View() string {
+	if m.width == 0 {
+		return docStyle.Render(m.list.View())
+	}
+
+	h, v := docStyle.GetFrameSize()
+	w := m.width - h - listWidth(m.width-h)
+
+	left, detail := m.tabBar(listWidth(m.width-h))+m.list.View(), ""
+	switch item, ok := m.list.SelectedItem().(comicItem); {
+	case m.showCalendar:
+		left = lipgloss.NewStyle().Width(listWidth(m.width - h)).Render(m.cal.View())
+		detail = renderDay(m.cal.day, releasedOn(m.books, m.cal.day))
+	case ok:
+		detail = renderDetail(item, w-detailStyle.GetHorizontalFrameSize())
+	}
+
+	height := m.height - v
+	pane := detailStyle.Width(w - detailStyle.GetHorizontalBorderSize()).
+		Height(height - detailStyle.GetVerticalFrameSize()).
+		MaxHeight(height)
+	return docStyle.Render(lipgloss.JoinHorizontal(lipgloss.Top, left, pane.Render(detail)))
+}
+
+// listWidth is the part of the width the list takes up next to the detail pane.
+func listWidth(width int) int {
+	return width * 45 / 100
+}
+
+func (m model) title() string {
+	if !m.day.IsZero() {
+		return "Released on " + m.day.Format("Mon Jan 2, 2006")
+	}
+
+	return "Comic Book Solicitations by " + string(m.sort)
+}
+
+// newModel builds the view of the books. The pull list can only be edited when p is set.
+func newModel(ctx context.Context, cbs []models.ComicBook, statuses map[string]string, s models.BookSort,
+	p pullList) *model {
+	if statuses == nil {
+		statuses = make(map[string]string)
+	}
+
+	m := model{
+		ctx:      ctx,
+		books:    cbs,
+		statuses: statuses,
+		selected: make(map[string]bool),
+		pull:     p,
+		sort:     s,
+		keys:     newViewKeys(),
+		tabs:     newTabs(cbs),
+		cursors:  make(map[int]int),
+	}
+
+	day := time.Now()
+	if i := slices.IndexFunc(cbs, func(cb models.ComicBook) bool { return !cb.ReleaseDate.IsZero() }); i >= 0 {
+		day = cbs[i].ReleaseDate
+	}
+	m.cal = newCalendar(cbs, day)
 
-	m := model{list: list.New(items, list.NewDefaultDelegate(), 0, 0)}
-	m.list.Title = "Comic Book Solicitations"
+	m.list = list.New(m.items(), viewDelegate{list.NewDefaultDelegate()}, 0, 0)
+	m.list.Title = m.title()
+	m.list.AdditionalShortHelpKeys = m.keys.shortBindings
+	m.list.AdditionalFullHelpKeys = m.keys.bindings
+	m.skipHeaders(1)
 
 	return &m
 }
+
+// collectionStatuses returns the status of every book in the collection, keyed on collectionKey.
+func (c *CLI) collectionStatuses(ctx context.Context) (map[string]string, error) {
+	statuses := make(map[string]string)
+	if c.collectionService == nil {
+		return statuses, nil
+	}
+
+	items, err := c.collectionService.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, it := range items {
+		statuses[collectionKey(it.ComicBook)] = it.Status
+	}
+
+	return statuses, nil
+}
+
+func collectionKey(cb models.ComicBook) string {
+	return strings.Join([]string{cb.Title, cb.Issue, cb.Publisher, cb.ReleaseDate.Format(time.DateOnly)}, "|")
+}
@@ -0,0 +1,235 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"strings"
+	"time"
+)
+
+var (
+	calendarTitleStyle = lipgloss.NewStyle().Bold(true).Padding(0, 1).
+				Background(lipgloss.Color("62")).Foreground(lipgloss.Color("230"))
+	dayStyle       = lipgloss.NewStyle().Width(7)
+	wednesdayStyle = dayStyle.Foreground(lipgloss.Color("#FFD700"))
+	cursorStyle    = dayStyle.Reverse(true)
+	mutedStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+)
+
+// calendarKeys move the cursor through the calendar. The cursor moves to the next or previous month when it leaves
+// the one on screen.
+type calendarKeys struct {
+	prevDay   key.Binding
+	nextDay   key.Binding
+	prevWeek  key.Binding
+	nextWeek  key.Binding
+	prevMonth key.Binding
+	nextMonth key.Binding
+	open      key.Binding
+	back      key.Binding
+	quit      key.Binding
+}
+
+func newCalendarKeys() calendarKeys {
+	return calendarKeys{
+		prevDay:   key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("←/h", "day")),
+		nextDay:   key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("→/l", "day")),
+		prevWeek:  key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "week")),
+		nextWeek:  key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "week")),
+		prevMonth: key.NewBinding(key.WithKeys("[", "pgup"), key.WithHelp("[", "previous month")),
+		nextMonth: key.NewBinding(key.WithKeys("]", "pgdown"), key.WithHelp("]", "next month")),
+		open:      key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "show day")),
+		back:      key.NewBinding(key.WithKeys("c", "esc"), key.WithHelp("c", "list")),
+		quit:      key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "quit")),
+	}
+}
+
+func (k calendarKeys) help() string {
+	var parts []string
+	for _, b := range []key.Binding{k.prevDay, k.prevWeek, k.prevMonth, k.nextMonth, k.open, k.back, k.quit} {
+		parts = append(parts, b.Help().Key+" "+b.Help().Desc)
+	}
+
+	return mutedStyle.Render(strings.Join(parts, " • "))
+}
+
+// calendar counts the releases per day and keeps the day under the cursor.
+type calendar struct {
+	day    time.Time
+	counts map[time.Time]int
+	keys   calendarKeys
+}
+
+func newCalendar(cbs []models.ComicBook, day time.Time) calendar {
+	c := calendar{day: dateOf(day), counts: make(map[time.Time]int), keys: newCalendarKeys()}
+	for _, cb := range cbs {
+		if !cb.ReleaseDate.IsZero() {
+			c.counts[dateOf(cb.ReleaseDate)]++
+		}
+	}
+
+	return c
+}
+
+// dateOf drops the time of day, so books released on the same day share a key.
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// month returns the first day of the month shown.
+func (c calendar) month() time.Time {
+	return time.Date(c.day.Year(), c.day.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// move handles the navigation keys and reports whether msg was one of them.
+func (c *calendar) move(msg tea.KeyMsg) bool {
+	switch {
+	case key.Matches(msg, c.keys.prevDay):
+		c.day = c.day.AddDate(0, 0, -1)
+	case key.Matches(msg, c.keys.nextDay):
+		c.day = c.day.AddDate(0, 0, 1)
+	case key.Matches(msg, c.keys.prevWeek):
+		c.day = c.day.AddDate(0, 0, -7)
+	case key.Matches(msg, c.keys.nextWeek):
+		c.day = c.day.AddDate(0, 0, 7)
+	case key.Matches(msg, c.keys.prevMonth):
+		c.day = addMonths(c.day, -1)
+	case key.Matches(msg, c.keys.nextMonth):
+		c.day = addMonths(c.day, 1)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// addMonths moves t by n months, keeping the day within the month: Jan 31 moves to Feb 28.
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+
+	return first.AddDate(0, 0, min(t.Day(), last)-1)
+}
+
+// View lays out the month as a grid starting on Monday with the number of releases on every day, followed by the
+// totals of the Wednesdays, when new comics come out.
+func (c calendar) View() string {
+	month := c.month()
+
+	var b strings.Builder
+	b.WriteString(calendarTitleStyle.Render(month.Format("January 2006")) + "\n\n")
+
+	for _, d := range []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"} {
+		b.WriteString(dayStyle.Render(d))
+	}
+	b.WriteString("\n")
+
+	start := weekOf(month)
+	for week := start; week.Month() == month.Month() || week.Equal(start); week = week.AddDate(0, 0, 7) {
+		for i := range 7 {
+			b.WriteString(c.cell(week.AddDate(0, 0, i)))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+
+	total := 0
+	for d := month; d.Month() == month.Month(); d = d.AddDate(0, 0, 1) {
+		total += c.counts[d]
+		if d.Weekday() == time.Wednesday {
+			fmt.Fprintf(&b, "%s  %d releases\n", d.Format("Wed Jan _2"), c.counts[d])
+		}
+	}
+
+	fmt.Fprintf(&b, "\n%d releases in %s\n\n", total, month.Format("January"))
+	b.WriteString(c.keys.help())
+
+	return b.String()
+}
+
+func (c calendar) cell(d time.Time) string {
+	if d.Month() != c.month().Month() {
+		return dayStyle.Render("")
+	}
+
+	s := fmt.Sprintf("%2d", d.Day())
+	if n := c.counts[d]; n > 0 {
+		s += fmt.Sprintf(" (%d)", n)
+	}
+
+	switch {
+	case d.Equal(c.day):
+		return cursorStyle.Render(s)
+	case d.Weekday() == time.Wednesday:
+		return wednesdayStyle.Render(s)
+	case c.counts[d] == 0:
+		return mutedStyle.Inherit(dayStyle).Render(s)
+	default:
+		return dayStyle.Render(s)
+	}
+}
+
+// releasedOn returns the books released on the day.
+func releasedOn(cbs []models.ComicBook, day time.Time) []models.ComicBook {
+	var books []models.ComicBook
+	for _, cb := range cbs {
+		if !cb.ReleaseDate.IsZero() && dateOf(cb.ReleaseDate).Equal(day) {
+			books = append(books, cb)
+		}
+	}
+
+	return books
+}
+
+// updateCalendar handles the keys while the calendar is shown.
+func (m *model) updateCalendar(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case m.cal.move(msg):
+		return nil
+	case key.Matches(msg, m.cal.keys.open):
+		m.showCalendar = false
+		return m.showDay(m.cal.day)
+	case key.Matches(msg, m.cal.keys.back):
+		m.showCalendar = false
+		return nil
+	case key.Matches(msg, m.cal.keys.quit):
+		return tea.Quit
+	default:
+		return nil
+	}
+}
+
+// showDay limits the list to the books released on day, or shows all books again for a zero day.
+func (m *model) showDay(day time.Time) tea.Cmd {
+	m.day = day
+	m.resetCursors()
+	m.list.ResetFilter()
+	m.list.Title = m.title()
+
+	cmd := m.list.SetItems(m.items())
+	m.list.ResetSelected()
+	m.skipHeaders(1)
+
+	return cmd
+}
+
+// renderDay lists the books released on the day under the cursor of the calendar.
+func renderDay(day time.Time, cbs []models.ComicBook) string {
+	var b strings.Builder
+	b.WriteString(labelStyle.Render(day.Format("Monday January 2, 2006")) + "\n\n")
+
+	if len(cbs) == 0 {
+		b.WriteString(mutedStyle.Render("No releases"))
+		return b.String()
+	}
+
+	for _, cb := range cbs {
+		fmt.Fprintf(&b, "%s [%s]\n", bookTitle(cb), strings.ToUpper(cb.Publisher))
+	}
+
+	return b.String()
+}
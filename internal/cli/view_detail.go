@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sortByPrice is only offered in the TUI: prices are stored as the text on the page, so the database cannot order
+// on them.
+const sortByPrice models.BookSort = "price"
+
+var (
+	headerStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FFD700")).PaddingTop(1).PaddingLeft(2)
+	detailStyle = lipgloss.NewStyle().Border(lipgloss.NormalBorder(), false, false, false, true).Padding(0, 2)
+	labelStyle  = lipgloss.NewStyle().Bold(true)
+
+	rePrice = regexp.MustCompile(`\d+(?:\.\d+)?`)
+)
+
+// weekHeader groups the books released in the week starting on Monday. A zero week holds the books without a
+// release date.
+type weekHeader struct {
+	week time.Time
+}
+
+func (h weekHeader) Title() string {
+	if h.week.IsZero() {
+		return "No release date"
+	}
+
+	return "Week of " + h.week.Format("Jan 2, 2006")
+}
+
+func (h weekHeader) Description() string { return "" }
+
+// FilterValue is empty, so headers drop out of the list while filtering.
+func (h weekHeader) FilterValue() string { return "" }
+
+// viewDelegate renders week headers itself and leaves the books to the default delegate.
+type viewDelegate struct {
+	list.DefaultDelegate
+}
+
+func (d viewDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	if h, ok := item.(weekHeader); ok {
+		fmt.Fprint(w, headerStyle.Render(h.Title()))
+		return
+	}
+
+	d.DefaultDelegate.Render(w, m, index, item)
+}
+
+// weekOf returns the Monday starting the week of t.
+func weekOf(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+
+	return t.AddDate(0, 0, -(int(t.Weekday())+6)%7)
+}
+
+// price reads the amount from a price like "$4.99". Books without one report false.
+func price(cb models.ComicBook) (float64, bool) {
+	m := rePrice.FindString(cb.Price)
+	if m == "" {
+		return 0, false
+	}
+
+	p, err := strconv.ParseFloat(m, 64)
+	return p, err == nil
+}
+
+// compareBooks orders books like the database does, with price as an extra order. Books without a price or release
+// date go last.
+func compareBooks(s models.BookSort) func(a, b models.ComicBook) int {
+	release := func(a, b models.ComicBook) int {
+		if a.ReleaseDate.IsZero() != b.ReleaseDate.IsZero() {
+			if a.ReleaseDate.IsZero() {
+				return 1
+			}
+			return -1
+		}
+
+		return a.ReleaseDate.Compare(b.ReleaseDate)
+	}
+	publisher := func(a, b models.ComicBook) int {
+		return strings.Compare(strings.ToLower(a.Publisher), strings.ToLower(b.Publisher))
+	}
+	title := func(a, b models.ComicBook) int {
+		return cmp.Or(
+			strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title)),
+			models.ParseIssue(a.Issue).Compare(models.ParseIssue(b.Issue)),
+		)
+	}
+	amount := func(a, b models.ComicBook) int {
+		pa, oka := price(a)
+		pb, okb := price(b)
+		if oka != okb {
+			if oka {
+				return -1
+			}
+			return 1
+		}
+
+		return cmp.Compare(pa, pb)
+	}
+
+	return func(a, b models.ComicBook) int {
+		switch s {
+		case models.SortByTitle:
+			return cmp.Or(title(a, b), release(a, b), publisher(a, b))
+		case models.SortByPublisher:
+			return cmp.Or(publisher(a, b), release(a, b), title(a, b))
+		case sortByPrice:
+			return cmp.Or(amount(a, b), release(a, b), publisher(a, b), title(a, b))
+		default:
+			return cmp.Or(release(a, b), publisher(a, b), title(a, b))
+		}
+	}
+}
+
+// groupItems sorts the books into list items. Sorted by release, the books are grouped under week headers.
+func groupItems(cbs []models.ComicBook, statuses map[string]string, s models.BookSort) []list.Item {
+	sorted := slices.SortedStableFunc(slices.Values(cbs), compareBooks(s))
+	items := make([]list.Item, 0, len(sorted))
+
+	for i, cb := range sorted {
+		if s == models.SortByRelease {
+			if week := weekOf(cb.ReleaseDate); i == 0 || !week.Equal(weekOf(sorted[i-1].ReleaseDate)) {
+				items = append(items, weekHeader{week: week})
+			}
+		}
+
+		items = append(items, comicItem{cb: &sorted[i], status: statuses[collectionKey(cb)]})
+	}
+
+	return items
+}
+
+// renderDetail shows everything known about the book, with the solicitation text wrapped to the width of the pane.
+func renderDetail(item comicItem, width int) string {
+	cb := item.cb
+
+	var b strings.Builder
+	b.WriteString(labelStyle.Render(bookTitle(*cb)) + "\n\n")
+
+	fields := []struct{ label, value string }{
+		{"Publisher", strings.ToUpper(cb.Publisher)},
+		{"Released", releaseDate(*cb)},
+		{"Format", cb.Format},
+		{"Price", cb.Price},
+		{"Pages", cb.Pages},
+		{"Status", item.status},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+
+		fmt.Fprintf(&b, "%s %s\n", labelStyle.Render(f.label+":"), f.value)
+	}
+
+	if len(cb.Creators) > 0 {
+		b.WriteString("\n" + labelStyle.Render("Creators") + "\n")
+		for _, credit := range credits(cb.Creators) {
+			b.WriteString("  " + credit + "\n")
+		}
+	}
+
+	if cb.Description != "" {
+		b.WriteString("\n" + lipgloss.NewStyle().Width(max(width, 1)).Render(cb.Description) + "\n")
+	}
+
+	if cb.URL != "" {
+		b.WriteString("\n" + cb.URL)
+	}
+
+	return b.String()
+}
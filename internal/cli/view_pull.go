@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"slices"
+)
+
+// pullList is the part of the collection the view edits.
+type pullList interface {
+	Pull(ctx context.Context, cbs []models.ComicBook) ([]models.ComicBook, error)
+	Unpull(ctx context.Context, cbs []models.ComicBook) ([]models.ComicBook, error)
+}
+
+// pullMsg reports the books that were added to or removed from the pull list.
+type pullMsg struct {
+	added bool
+	books []models.ComicBook
+	err   error
+}
+
+// items groups the books on the current tab into list items in the current order and marks the selected ones. After
+// picking a day in the calendar only the books released on it are listed.
+func (m model) items() []list.Item {
+	cbs := m.tabs[m.tab].filter(m.books)
+	if !m.day.IsZero() {
+		cbs = releasedOn(cbs, m.day)
+	}
+
+	items := groupItems(cbs, m.statuses, m.sort)
+	for i, it := range items {
+		if ci, ok := it.(comicItem); ok && m.selected[collectionKey(*ci.cb)] {
+			ci.selected = true
+			items[i] = ci
+		}
+	}
+
+	return items
+}
+
+func (m *model) toggleSelected() tea.Cmd {
+	ci, ok := m.list.SelectedItem().(comicItem)
+	if !ok {
+		return nil
+	}
+
+	k := collectionKey(*ci.cb)
+	if m.selected[k] {
+		delete(m.selected, k)
+	} else {
+		m.selected[k] = true
+	}
+
+	ci.selected = m.selected[k]
+	return m.list.SetItem(m.list.GlobalIndex(), ci)
+}
+
+// targets returns the selected books, or the book under the cursor when nothing is selected.
+func (m model) targets() []models.ComicBook {
+	if len(m.selected) == 0 {
+		if ci, ok := m.list.SelectedItem().(comicItem); ok {
+			return []models.ComicBook{*ci.cb}
+		}
+
+		return nil
+	}
+
+	var cbs []models.ComicBook
+	for _, cb := range m.books {
+		if m.selected[collectionKey(cb)] {
+			cbs = append(cbs, cb)
+		}
+	}
+
+	return cbs
+}
+
+// series returns every book in the view that belongs to the series of one of cbs.
+func (m model) series(cbs []models.ComicBook) []models.ComicBook {
+	keys := make(map[string]bool)
+	for _, cb := range cbs {
+		s, _ := models.SeriesOf(cb)
+		keys[s.Key()] = true
+	}
+
+	var books []models.ComicBook
+	for _, cb := range m.books {
+		if s, _ := models.SeriesOf(cb); keys[s.Key()] {
+			books = append(books, cb)
+		}
+	}
+
+	return books
+}
+
+// togglePull adds the books to the pull list when any of them is not in the collection yet, and takes them off
+// otherwise. The change is saved in the background and reported with a pullMsg.
+func (m *model) togglePull(cbs []models.ComicBook) tea.Cmd {
+	if len(cbs) == 0 {
+		return nil
+	}
+
+	if m.pull == nil {
+		return m.list.NewStatusMessage("The pull list can not be edited without a collection")
+	}
+
+	add := slices.ContainsFunc(cbs, func(cb models.ComicBook) bool {
+		return m.statuses[collectionKey(cb)] == ""
+	})
+
+	ctx, p := m.ctx, m.pull
+	return func() tea.Msg {
+		msg := pullMsg{added: add}
+		if add {
+			msg.books, msg.err = p.Pull(ctx, cbs)
+		} else {
+			msg.books, msg.err = p.Unpull(ctx, cbs)
+		}
+
+		return msg
+	}
+}
+
+// applyPull updates the statuses with the saved change and clears the selection.
+func (m *model) applyPull(msg pullMsg) tea.Cmd {
+	for _, cb := range msg.books {
+		if msg.added {
+			m.statuses[collectionKey(cb)] = models.StatusWanted
+		} else {
+			delete(m.statuses, collectionKey(cb))
+		}
+	}
+
+	if msg.err != nil {
+		return tea.Batch(m.list.SetItems(m.items()),
+			m.list.NewStatusMessage(fmt.Sprintf("Failed to update the pull list: %v", msg.err)))
+	}
+
+	clear(m.selected)
+
+	status := fmt.Sprintf("Removed %d books from the pull list", len(msg.books))
+	if msg.added {
+		status = fmt.Sprintf("Added %d books to the pull list", len(msg.books))
+	}
+
+	return tea.Batch(m.list.SetItems(m.items()), m.list.NewStatusMessage(status))
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"slices"
+	"strings"
+)
+
+// bookFormats are the formats the scraper reads from the sections of a solicitation page.
+var bookFormats = []string{"singles", "trades", "hardcovers"}
+
+// tabBarHeight is the number of lines the tab bar takes up above the list.
+const tabBarHeight = 2
+
+var (
+	activeTabStyle = lipgloss.NewStyle().Bold(true).Padding(0, 1).
+			Background(lipgloss.Color("62")).Foreground(lipgloss.Color("230"))
+	inactiveTabStyle = lipgloss.NewStyle().Padding(0, 1).Foreground(lipgloss.Color("245"))
+)
+
+// viewTab shows part of the books. The first tab has no match and shows them all.
+type viewTab struct {
+	name  string
+	match func(cb models.ComicBook) bool
+}
+
+// newTabs returns the "All" tab, a tab for every publisher in the books and one for every format.
+func newTabs(cbs []models.ComicBook) []viewTab {
+	tabs := []viewTab{{name: "All"}}
+
+	var publishers []string
+	for _, cb := range cbs {
+		if p := strings.ToLower(cb.Publisher); p != "" && !slices.Contains(publishers, p) {
+			publishers = append(publishers, p)
+		}
+	}
+	slices.Sort(publishers)
+
+	for _, p := range publishers {
+		tabs = append(tabs, viewTab{
+			name:  strings.ToUpper(p),
+			match: func(cb models.ComicBook) bool { return strings.EqualFold(cb.Publisher, p) },
+		})
+	}
+
+	for _, f := range bookFormats {
+		tabs = append(tabs, viewTab{
+			name:  strings.ToUpper(f[:1]) + f[1:],
+			match: func(cb models.ComicBook) bool { return cb.Format == f },
+		})
+	}
+
+	return tabs
+}
+
+// filter returns the books shown on the tab.
+func (t viewTab) filter(cbs []models.ComicBook) []models.ComicBook {
+	if t.match == nil {
+		return cbs
+	}
+
+	var books []models.ComicBook
+	for _, cb := range cbs {
+		if t.match(cb) {
+			books = append(books, cb)
+		}
+	}
+
+	return books
+}
+
+// switchTab moves dir tabs over, wrapping around at either end, and clears the filter. The cursor of every tab is
+// remembered, so going back to a tab returns to the book that was selected on it.
+func (m *model) switchTab(dir int) tea.Cmd {
+	m.cursors[m.tab] = m.list.Index()
+	m.tab = (m.tab + dir + len(m.tabs)) % len(m.tabs)
+
+	m.list.ResetFilter()
+	cmd := m.list.SetItems(m.items())
+
+	i := m.cursors[m.tab]
+	if n := len(m.list.VisibleItems()); i >= n {
+		i = max(n-1, 0)
+	}
+
+	m.list.Select(i)
+	m.skipHeaders(1)
+
+	return cmd
+}
+
+// resetCursors forgets the remembered cursors when the books on the tabs change order.
+func (m *model) resetCursors() {
+	clear(m.cursors)
+}
+
+// tabBar renders the tabs with the number of books on them, limited to width.
+func (m model) tabBar(width int) string {
+	cbs := m.books
+	if !m.day.IsZero() {
+		cbs = releasedOn(cbs, m.day)
+	}
+
+	tabs := make([]string, 0, len(m.tabs))
+	for i, t := range m.tabs {
+		label := fmt.Sprintf("%s %d", t.name, len(t.filter(cbs)))
+		if i == m.tab {
+			tabs = append(tabs, activeTabStyle.Render(label))
+		} else {
+			tabs = append(tabs, inactiveTabStyle.Render(label))
+		}
+	}
+
+	return lipgloss.NewStyle().MaxWidth(width).Render(lipgloss.JoinHorizontal(lipgloss.Top, tabs...)) + "\n"
+}
@@ -0,0 +1,330 @@
+package cli
+
+import (
+	"context"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func viewBooks() []models.ComicBook {
+	date := func(d int) time.Time { return time.Date(2026, time.March, d, 0, 0, 0, 0, time.UTC) }
+
+	return []models.ComicBook{
+		{Title: "Saga", Issue: "10", Publisher: "image", Price: "$3.99", ReleaseDate: date(11)},
+		{Title: "Batman", Issue: "2", Publisher: "dc", Price: "$4.99", ReleaseDate: date(18)},
+		{Title: "Saga", Issue: "9", Publisher: "image", ReleaseDate: date(4)},
+		{Title: "Absolute Batman", Issue: "1", Publisher: "dc", Price: "$5.99", ReleaseDate: date(4)},
+		{Title: "Monstress", Issue: "1", Publisher: "image", Price: "$2.99"},
+	}
+}
+
+func itemTitles(items []list.Item) []string {
+	var titles []string
+	for _, it := range items {
+		switch it := it.(type) {
+		case weekHeader:
+			titles = append(titles, it.Title())
+		case comicItem:
+			titles = append(titles, bookTitle(*it.cb))
+		}
+	}
+
+	return titles
+}
+
+func Test_groupItems(t *testing.T) {
+	tests := []struct {
+		name string
+		sort models.BookSort
+		want []string
+	}{
+		{
+			name: "release grouped by week",
+			sort: models.SortByRelease,
+			want: []string{
+				"Week of Mar 2, 2026", "Absolute Batman #1", "Saga #9",
+				"Week of Mar 9, 2026", "Saga #10",
+				"Week of Mar 16, 2026", "Batman #2",
+				"No release date", "Monstress #1",
+			},
+		},
+		{
+			name: "title",
+			sort: models.SortByTitle,
+			want: []string{"Absolute Batman #1", "Batman #2", "Monstress #1", "Saga #9", "Saga #10"},
+		},
+		{
+			name: "publisher",
+			sort: models.SortByPublisher,
+			want: []string{"Absolute Batman #1", "Batman #2", "Saga #9", "Saga #10", "Monstress #1"},
+		},
+		{
+			name: "price without price last",
+			sort: sortByPrice,
+			want: []string{"Monstress #1", "Saga #10", "Batman #2", "Absolute Batman #1", "Saga #9"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := itemTitles(groupItems(viewBooks(), nil, tt.sort))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("groupItems() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModel_SkipsHeaders(t *testing.T) {
+	m := newModel(context.Background(), viewBooks(), nil, models.SortByRelease, nil)
+	m.list.SetSize(80, 100)
+
+	selected := func(m tea.Model) string {
+		item, ok := m.(model).list.SelectedItem().(comicItem)
+		if !ok {
+			t.Fatalf("selected item = %v, want a book", m.(model).list.SelectedItem())
+		}
+		return bookTitle(*item.cb)
+	}
+
+	if got := m.list.SelectedItem().(comicItem); bookTitle(*got.cb) != "Absolute Batman #1" {
+		t.Errorf("first selected = %v, want Absolute Batman #1", bookTitle(*got.cb))
+	}
+
+	var got tea.Model = *m
+	for _, want := range []string{"Saga #9", "Saga #10", "Batman #2"} {
+		got, _ = got.Update(tea.KeyMsg{Type: tea.KeyDown})
+		if s := selected(got); s != want {
+			t.Errorf("after down selected = %v, want %v", s, want)
+		}
+	}
+
+	got, _ = got.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if s := selected(got); s != "Saga #10" {
+		t.Errorf("after up selected = %v, want Saga #10", s)
+	}
+
+	got, _ = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if s := selected(got); s != "Absolute Batman #1" {
+		t.Errorf("after sorting by title selected = %v, want Absolute Batman #1", s)
+	}
+	if got.(model).sort != models.SortByTitle {
+		t.Errorf("sort = %v, want %v", got.(model).sort, models.SortByTitle)
+	}
+}
+
+func Test_renderDetail(t *testing.T) {
+	cb := models.ComicBook{
+		Title:     "Batman",
+		Issue:     "2",
+		Publisher: "dc",
+		Format:    "singles",
+		Price:     "$4.99",
+		Pages:     "32",
+		Creators: []models.Creator{
+			{Name: "Tom King", Role: "writer"},
+			{Name: "Tom King", Role: "cover artist"},
+			{Name: "Jorge Jiménez", Role: "artist"},
+		},
+		Description: "The Dark Knight returns to Gotham City.",
+	}
+
+	got := renderDetail(comicItem{cb: &cb, status: models.StatusWanted}, 20)
+
+	for _, want := range []string{
+		"Batman #2", "DC", "singles", "$4.99", "32", models.StatusWanted,
+		"Tom King (writer, cover artist)", "Jorge Jiménez (artist)", "Gotham",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("renderDetail() = %q, want it to contain %q", got, want)
+		}
+	}
+
+	for _, line := range strings.Split(got, "\n") {
+		if strings.HasPrefix(line, "The Dark") && len(line) > 20 {
+			t.Errorf("renderDetail() line %q is not wrapped to 20 columns", line)
+		}
+	}
+}
+
+type fakePullList struct {
+	pulled, unpulled []models.ComicBook
+}
+
+func (f *fakePullList) Pull(_ context.Context, cbs []models.ComicBook) ([]models.ComicBook, error) {
+	f.pulled = append(f.pulled, cbs...)
+	return cbs, nil
+}
+
+func (f *fakePullList) Unpull(_ context.Context, cbs []models.ComicBook) ([]models.ComicBook, error) {
+	f.unpulled = append(f.unpulled, cbs...)
+	return cbs, nil
+}
+
+func TestModel_TogglePull(t *testing.T) {
+	p := &fakePullList{}
+	m := newModel(context.Background(), viewBooks(), nil, models.SortByTitle, p)
+	m.list.SetSize(80, 100)
+
+	keys := func(m tea.Model, ks ...tea.KeyMsg) tea.Model {
+		for _, k := range ks {
+			var cmd tea.Cmd
+			m, cmd = m.Update(k)
+			if cmd == nil {
+				continue
+			}
+
+			if msg, ok := cmd().(pullMsg); ok {
+				m, _ = m.Update(msg)
+			}
+		}
+		return m
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	down := tea.KeyMsg{Type: tea.KeyDown}
+
+	// Select Absolute Batman #1 and Monstress #1 and add them to the pull list.
+	got := keys(*m, tea.KeyMsg{Type: tea.KeySpace}, down, down, tea.KeyMsg{Type: tea.KeySpace}, runes("w"))
+	if names := titles(p.pulled); !slices.Equal(names, []string{"Absolute Batman", "Monstress"}) {
+		t.Errorf("pulled = %v, want [Absolute Batman Monstress]", names)
+	}
+
+	item := got.(model).list.SelectedItem().(comicItem)
+	if !strings.Contains(item.Description(), "pull list: wanted") {
+		t.Errorf("Description() = %q, want a pull list indicator", item.Description())
+	}
+	if len(got.(model).selected) != 0 {
+		t.Errorf("selected = %v, want the selection cleared", got.(model).selected)
+	}
+
+	// Toggling the series of Monstress takes it off again.
+	keys(got, runes("W"))
+	if names := titles(p.unpulled); !slices.Equal(names, []string{"Monstress"}) {
+		t.Errorf("unpulled = %v, want [Monstress]", names)
+	}
+
+	// The whole Saga series is added from a single issue.
+	keys(got, down, down, runes("W"))
+	if names := titles(p.pulled[2:]); !slices.Equal(names, []string{"Saga", "Saga"}) {
+		t.Errorf("pulled = %v, want both Saga issues", names)
+	}
+}
+
+func titles(cbs []models.ComicBook) []string {
+	var names []string
+	for _, cb := range cbs {
+		names = append(names, cb.Title)
+	}
+	return names
+}
+
+func Test_addMonths(t *testing.T) {
+	tests := []struct {
+		t    time.Time
+		n    int
+		want time.Time
+	}{
+		{t: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), n: 1, want: time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{t: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), n: -1, want: time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC)},
+		{t: time.Date(2026, 12, 15, 0, 0, 0, 0, time.UTC), n: 1, want: time.Date(2027, 1, 15, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.t.Format(time.DateOnly), func(t *testing.T) {
+			if got := addMonths(tt.t, tt.n); !got.Equal(tt.want) {
+				t.Errorf("addMonths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendar_View(t *testing.T) {
+	c := newCalendar(viewBooks(), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	got := c.View()
+
+	for _, want := range []string{"March 2026", " 4 (2)", "11 (1)", "18 (1)", "Wed Mar  4  2 releases",
+		"Wed Mar 25  0 releases", "4 releases in March"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("View() = %q, want it to contain %q", got, want)
+		}
+	}
+}
+
+func TestModel_Calendar(t *testing.T) {
+	m := newModel(context.Background(), viewBooks(), nil, models.SortByRelease, nil)
+	m.list.SetSize(80, 100)
+
+	var got tea.Model = *m
+	for _, k := range []string{"c", "l", "l", "l", "l", "l", "l", "l"} {
+		got, _ = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	}
+
+	if day := got.(model).cal.day; !day.Equal(time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("calendar day = %v, want Mar 11", day)
+	}
+
+	got, _ = got.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got.(model).showCalendar {
+		t.Fatal("showCalendar = true, want the list of the day")
+	}
+
+	if items := itemTitles(got.(model).list.Items()); !slices.Equal(items, []string{"Week of Mar 9, 2026", "Saga #10"}) {
+		t.Errorf("items = %v, want only the books of Mar 11", items)
+	}
+
+	got, _ = got.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !got.(model).showCalendar || len(got.(model).list.Items()) != 9 {
+		t.Errorf("after esc showCalendar = %v with %d items, want the calendar and all items",
+			got.(model).showCalendar, len(got.(model).list.Items()))
+	}
+}
+
+func TestModel_Tabs(t *testing.T) {
+	cbs := viewBooks()
+	cbs[0].Format, cbs[2].Format = "singles", "singles"
+	cbs[4].Format = "trades"
+
+	m := newModel(context.Background(), cbs, nil, models.SortByTitle, nil)
+	m.list.SetSize(80, 100)
+
+	names := make([]string, 0, len(m.tabs))
+	for _, tab := range m.tabs {
+		names = append(names, tab.name)
+	}
+	if want := []string{"All", "DC", "IMAGE", "Singles", "Trades", "Hardcovers"}; !slices.Equal(names, want) {
+		t.Errorf("tabs = %v, want %v", names, want)
+	}
+
+	if bar := m.tabBar(200); !strings.Contains(bar, "All 5") || !strings.Contains(bar, "Singles 2") ||
+		!strings.Contains(bar, "Hardcovers 0") {
+		t.Errorf("tabBar() = %q, want the number of books on every tab", bar)
+	}
+
+	var got tea.Model = *m
+	key := func(k tea.KeyMsg) { got, _ = got.Update(k) }
+
+	key(tea.KeyMsg{Type: tea.KeyDown})
+	key(tea.KeyMsg{Type: tea.KeyTab})
+	key(tea.KeyMsg{Type: tea.KeyTab})
+	items := itemTitles(got.(model).list.Items())
+	if want := []string{"Monstress #1", "Saga #9", "Saga #10"}; !slices.Equal(items, want) {
+		t.Errorf("IMAGE tab items = %v, want %v", items, want)
+	}
+
+	key(tea.KeyMsg{Type: tea.KeyDown})
+	key(tea.KeyMsg{Type: tea.KeyDown})
+	key(tea.KeyMsg{Type: tea.KeyShiftTab})
+	key(tea.KeyMsg{Type: tea.KeyShiftTab})
+	if item := got.(model).list.SelectedItem().(comicItem); bookTitle(*item.cb) != "Batman #2" {
+		t.Errorf("All tab selected = %v, want the remembered Batman #2", bookTitle(*item.cb))
+	}
+
+	key(tea.KeyMsg{Type: tea.KeyTab})
+	key(tea.KeyMsg{Type: tea.KeyTab})
+	if item := got.(model).list.SelectedItem().(comicItem); bookTitle(*item.cb) != "Saga #10" {
+		t.Errorf("IMAGE tab selected = %v, want the remembered Saga #10", bookTitle(*item.cb))
+	}
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"io/fs"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Config holds the user settings read from config.json in the solipull config directory. Every setting is
+// optional, a missing file results in the defaults.
+type Config struct {
+	Sources    []Source             `json:"sources"`
+	Extractors map[string]Extractor `json:"extractors"`
+	// Retention deletes books released longer ago than this after every sync, like "24mo". Books in the collection
+	// or on the pull list are kept.
+	Retention models.Retention `json:"retention"`
+	// Sync holds the publishers and months synced without asking when the flags are left out and there is no
+	// terminal, or --no-input is given.
+	Sync models.SyncDefaults `json:"sync"`
+}
+
+// Source describes a publisher-direct solicitation source. The start URL is an index page linking to the monthly
+// solicitation pages, the selectors locate the books on those pages.
+type Source struct {
+	Name      string    `json:"name"`
+	Publisher string    `json:"publisher"`
+	Domain    string    `json:"domain"`
+	StartURL  string    `json:"start_url"`
+	Links     string    `json:"links"`
+	Selectors Selectors `json:"selectors"`
+}
+
+type Selectors struct {
+	Item        string `json:"item"`
+	Title       string `json:"title"`
+	Details     string `json:"details"`
+	ReleaseDate string `json:"release_date"`
+	Description string `json:"description"`
+	Format      string `json:"format"`
+}
+
+// Extractor overrides how books are extracted from the pages of the source with the same name. Fields that are not
+// set keep their built-in value. The layout fields only apply to sources without their own selectors.
+type Extractor struct {
+	Container   string   `json:"container"`
+	Title       Field    `json:"title"`
+	Details     Field    `json:"details"`
+	ReleaseDate Field    `json:"release_date"`
+	Description Field    `json:"description"`
+	Format      string   `json:"format"`
+	Patterns    Patterns `json:"patterns"`
+	DateLayouts []string `json:"date_layouts"`
+	Roles       []string `json:"roles"`
+}
+
+type Field struct {
+	Selector string `json:"selector"`
+	Index    int    `json:"index"`
+}
+
+// Patterns are the regular expressions used to pull values out of the text of a page.
+type Patterns struct {
+	Publisher   string `json:"publisher"`
+	Pages       string `json:"pages"`
+	Price       string `json:"price"`
+	ReleaseDate string `json:"release_date"`
+}
+
+func Load(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %v", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %v", path, err)
+	}
+
+	for i := range cfg.Sources {
+		if err := cfg.Sources[i].validate(); err != nil {
+			return nil, fmt.Errorf("invalid source %d in config: %v", i+1, err)
+		}
+	}
+
+	return &cfg, nil
+}
+
+func (s *Source) validate() error {
+	if s.Name == "" || s.Publisher == "" || s.StartURL == "" {
+		return errors.New("name, publisher and start_url are required")
+	}
+
+	if s.Domain != "" {
+		return nil
+	}
+
+	u, err := url.Parse(s.StartURL)
+	if err != nil || u.Hostname() == "" {
+		return fmt.Errorf("invalid start_url: %s", s.StartURL)
+	}
+
+	s.Domain = strings.TrimPrefix(u.Hostname(), "www.")
+	return nil
+}
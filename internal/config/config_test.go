@@ -0,0 +1,96 @@
+package config
+
+import (
+	"github.com/MikkelvtK/solipull/internal/models"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeConfig(content string, t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Error writing config: %v", err)
+	}
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    *Config
+		wantErr bool
+	}{
+		{
+			name:    "invalid json",
+			content: `{"sources": [`,
+			wantErr: true,
+		},
+		{
+			name:    "missing required fields",
+			content: `{"sources": [{"name": "image-direct"}]}`,
+			wantErr: true,
+		},
+		{
+			name: "derives domain from start url",
+			content: `{"sources": [{"name": "image-direct", "publisher": "image",
+				"start_url": "https://www.imagecomics.com/solicitations", "selectors": {"item": "div.solicit"}}]}`,
+			want: &Config{
+				Sources: []Source{
+					{
+						Name:      "image-direct",
+						Publisher: "image",
+						Domain:    "imagecomics.com",
+						StartURL:  "https://www.imagecomics.com/solicitations",
+						Selectors: Selectors{Item: "div.solicit"},
+					},
+				},
+			},
+		},
+		{
+			name:    "retention",
+			content: `{"retention": "24mo"}`,
+			want:    &Config{Retention: models.Retention{Months: 24}},
+		},
+		{
+			name:    "sync defaults",
+			content: `{"sync": {"publishers": ["dc", "image"], "months": ["current", "next"]}}`,
+			want: &Config{Sync: models.SyncDefaults{
+				Publishers: []string{"dc", "image"},
+				Months:     []string{"current", "next"},
+			}},
+		},
+		{
+			name:    "invalid retention",
+			content: `{"retention": "two years"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Load(writeConfig(tt.content, t))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Load() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	got, err := Load(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil {
+		t.Errorf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, &Config{}) {
+		t.Errorf("Load() got = %+v, want empty config", got)
+	}
+}
@@ -3,10 +3,7 @@ package database
 import (
 	"database/sql"
 	"embed"
-	"fmt"
-	"github.com/pressly/goose/v3"
-	"io"
-	"log"
+	"net/url"
 	"os"
 	"path/filepath"
 
@@ -16,34 +13,27 @@ import (
 //go:embed migrations
 var migrations embed.FS
 
-func MustOpen(path, driver string) *sql.DB {
-	dir := filepath.Dir(path)
-	err := os.MkdirAll(dir, os.ModePerm)
-	if err != nil && !os.IsExist(err) {
-		panic(fmt.Sprintf("Error opening db: %s", err.Error()))
+// pragmas are set on every connection. Foreign keys make deletes cascade, WAL with a busy timeout lets a sync and
+// the TUI use the database at the same time, and NORMAL synchronous is safe with WAL while syncing less often.
+var pragmas = []string{"foreign_keys(1)", "journal_mode(WAL)", "busy_timeout(5000)", "synchronous(NORMAL)"}
+
+// Open connects to the database at path with the connection pragmas set, and creates its directory when needed.
+// The schema is left as it is, so a half migrated database can still be inspected. Use Maintenance.Migrate to bring
+// it up to date.
+func Open(path, driver string) (*sql.DB, error) {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, err
 	}
 
-	db, err := sql.Open(driver, path)
+	db, err := sql.Open(driver, path+"?"+url.Values{"_pragma": pragmas}.Encode())
 	if err != nil {
-		panic(fmt.Sprintf("Error opening db: %s", err.Error()))
+		return nil, err
 	}
 
 	if err := db.Ping(); err != nil {
-		panic(fmt.Sprintf("Error opening db: %s", err.Error()))
-	}
-
-	// TODO: eventually redirect this to custom logger
-	discardLogger := log.New(io.Discard, "", 0)
-	goose.SetLogger(discardLogger)
-
-	goose.SetBaseFS(migrations)
-	if err := goose.SetDialect(driver); err != nil {
-		panic(fmt.Sprintf("Error opening db: %s", err.Error()))
-	}
-
-	if err := goose.Up(db, "migrations"); err != nil {
-		panic(fmt.Sprintf("Error opening db: %s", err.Error()))
+		db.Close()
+		return nil, err
 	}
 
-	return db
+	return db, nil
 }
@@ -0,0 +1,336 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Maintenance backs up and restores the database file at path, which db is connected to.
+type Maintenance struct {
+	db   *sql.DB
+	path string
+}
+
+func NewMaintenance(db *sql.DB, path string) *Maintenance {
+	return &Maintenance{db: db, path: path}
+}
+
+// Backup writes a consistent copy of the database to dst while it is in use. When dst is empty, the copy is written
+// to a timestamped file in the backups directory next to the database. The path of the copy is returned.
+func (m *Maintenance) Backup(ctx context.Context, dst string) (string, error) {
+	if dst == "" {
+		dst = backupPath(m.path, "")
+	}
+
+	return dst, backup(ctx, m.db, dst)
+}
+
+// Restore replaces the database with the backup at src, after backing up the current database. The connection is
+// closed, so the application has to exit afterward. Backups made by an older build are migrated on the next start,
+// backups of a newer build are refused.
+func (m *Maintenance) Restore(ctx context.Context, src string) error {
+	if err := checkBackup(ctx, src); err != nil {
+		return err
+	}
+
+	if _, err := m.Backup(ctx, ""); err != nil {
+		return fmt.Errorf("failed to back up the current database: %v", err)
+	}
+
+	if err := m.db.Close(); err != nil {
+		return fmt.Errorf("failed to close the database: %v", err)
+	}
+
+	tmp := m.path + ".restore"
+	if err := copyFile(src, tmp); err != nil {
+		return fmt.Errorf("failed to copy backup: %v", err)
+	}
+
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(m.path + suffix); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to remove %s: %v", m.path+suffix, err)
+		}
+	}
+
+	return os.Rename(tmp, m.path)
+}
+
+// checkBackup makes sure src is a solipull database this build can migrate.
+func checkBackup(ctx context.Context, src string) error {
+	if _, err := os.Stat(src); err != nil {
+		return err
+	}
+
+	db, err := sql.Open("sqlite", "file:"+src+"?mode=ro")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var version int64
+	err = db.QueryRowContext(ctx, "SELECT MAX(version_id) FROM goose_db_version WHERE is_applied").Scan(&version)
+	if err != nil {
+		return fmt.Errorf("%s is not a solipull database: %v", src, err)
+	}
+
+	latest, err := latestVersion()
+	if err != nil {
+		return err
+	}
+
+	if version > latest {
+		return fmt.Errorf("%s was made by a newer version of solipull (schema %d, this build supports %d)", src,
+			version, latest)
+	}
+
+	return nil
+}
+
+func backup(ctx context.Context, db *sql.DB, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(dst); err == nil {
+		return fmt.Errorf("%s already exists", dst)
+	}
+
+	if _, err := db.ExecContext(ctx, "VACUUM INTO ?", dst); err != nil {
+		return fmt.Errorf("failed to back up database: %v", err)
+	}
+
+	return nil
+}
+
+// backupPath returns a timestamped path in the backups directory next to the database at path, numbered when a
+// backup was already made in the same second.
+func backupPath(path, label string) string {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if label != "" {
+		name += "-" + label
+	}
+
+	name = fmt.Sprintf("%s-%s", name, time.Now().Format("20060102-150405"))
+
+	dst := filepath.Join(filepath.Dir(path), "backups", name+".db")
+	for i := 2; ; i++ {
+		if _, err := os.Stat(dst); errors.Is(err, fs.ErrNotExist) {
+			return dst
+		}
+
+		dst = filepath.Join(filepath.Dir(path), "backups", fmt.Sprintf("%s-%d.db", name, i))
+	}
+}
+
+// keepMigrationBackups is how many of the backups made before a migration are kept. Backups made with Backup are
+// never removed.
+const keepMigrationBackups = 5
+
+// pruneMigrationBackups removes all but the newest keepMigrationBackups backups made before migrating the database
+// at path.
+func pruneMigrationBackups(path string) error {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	matches, err := filepath.Glob(filepath.Join(filepath.Dir(path), "backups", name+"-v[0-9]*.db"))
+	if err != nil {
+		return err
+	}
+
+	if len(matches) <= keepMigrationBackups {
+		return nil
+	}
+
+	modified := make(map[string]time.Time, len(matches))
+	for _, f := range matches {
+		info, err := os.Stat(f)
+		if err != nil {
+			return err
+		}
+
+		modified[f] = info.ModTime()
+	}
+
+	slices.SortFunc(matches, func(a, b string) int {
+		if c := modified[b].Compare(modified[a]); c != 0 {
+			return c
+		}
+
+		return strings.Compare(b, a)
+	})
+
+	for _, f := range matches[keepMigrationBackups:] {
+		if err := os.Remove(f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// latestVersion returns the version of the newest embedded migration.
+func latestVersion() (int64, error) {
+	entries, err := fs.ReadDir(migrations, "migrations")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest int64
+	for _, e := range entries {
+		prefix, _, _ := strings.Cut(e.Name(), "_")
+		v, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		latest = max(latest, v)
+	}
+
+	return latest, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+// Check runs SQLite's integrity and foreign key checks and looks for credits that lost their book or person.
+func (m *Maintenance) Check(ctx context.Context) (models.IntegrityReport, error) {
+	var r models.IntegrityReport
+
+	rows, err := m.db.QueryContext(ctx, "PRAGMA integrity_check")
+	if err != nil {
+		return r, fmt.Errorf("failed to check integrity: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var msg string
+		if err := rows.Scan(&msg); err != nil {
+			return r, err
+		}
+
+		if msg != "ok" {
+			r.Integrity = append(r.Integrity, msg)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return r, err
+	}
+
+	violations, err := m.foreignKeyViolations(ctx)
+	if err != nil {
+		return r, err
+	}
+
+	// Credits without a book or person are reported as such instead of as plain foreign key violations.
+	for _, v := range violations {
+		switch {
+		case v.Table == "creators" && v.Parent == "comic_books":
+			r.OrphanCreators++
+		case v.Table == "creators" && v.Parent == "people":
+		default:
+			r.ForeignKeys = append(r.ForeignKeys, v)
+		}
+	}
+
+	if ok, err := m.hasColumn(ctx, "creators", "person_id"); err != nil || !ok {
+		return r, err
+	}
+
+	query := "SELECT COUNT(*) FROM creators WHERE person_id IS NULL OR person_id NOT IN (SELECT id FROM people)"
+	if err := m.db.QueryRowContext(ctx, query).Scan(&r.UnlinkedCreators); err != nil {
+		return r, fmt.Errorf("failed to count unlinked creators: %v", err)
+	}
+
+	return r, nil
+}
+
+func (m *Maintenance) foreignKeyViolations(ctx context.Context) ([]models.ForeignKeyViolation, error) {
+	rows, err := m.db.QueryContext(ctx, "PRAGMA foreign_key_check")
+	if err != nil {
+		return nil, fmt.Errorf("failed to check foreign keys: %v", err)
+	}
+	defer rows.Close()
+
+	var result []models.ForeignKeyViolation
+	for rows.Next() {
+		var (
+			v     models.ForeignKeyViolation
+			rowID sql.NullInt64
+			fkID  int
+		)
+
+		if err := rows.Scan(&v.Table, &rowID, &v.Parent, &fkID); err != nil {
+			return nil, err
+		}
+
+		v.RowID = rowID.Int64
+		result = append(result, v)
+	}
+
+	return result, rows.Err()
+}
+
+// hasColumn reports whether table has column, so the checks also run on a database that is not fully migrated.
+func (m *Maintenance) hasColumn(ctx context.Context, table, column string) (bool, error) {
+	var n int
+	query := "SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?"
+	if err := m.db.QueryRowContext(ctx, query, table, column).Scan(&n); err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+// Vacuum rebuilds the database file to reclaim the space of deleted rows, and returns its size before and after in
+// bytes.
+func (m *Maintenance) Vacuum(ctx context.Context) (int64, int64, error) {
+	before, err := m.size(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if _, err := m.db.ExecContext(ctx, "VACUUM"); err != nil {
+		return before, 0, fmt.Errorf("failed to vacuum database: %v", err)
+	}
+
+	after, err := m.size(ctx)
+	return before, after, err
+}
+
+func (m *Maintenance) size(ctx context.Context) (int64, error) {
+	var size int64
+	query := "SELECT page_count * page_size FROM pragma_page_count(), pragma_page_size()"
+	if err := m.db.QueryRowContext(ctx, query).Scan(&size); err != nil {
+		return 0, fmt.Errorf("failed to read database size: %v", err)
+	}
+
+	return size, nil
+}
@@ -0,0 +1,472 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// openDB opens the database at path and migrates it to the latest schema.
+func openDB(t *testing.T, path string) *sql.DB {
+	t.Helper()
+
+	db, err := Open(path, "sqlite")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	if _, err := NewMaintenance(db, path).Migrate(context.Background()); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	return db
+}
+
+func countBooks(t *testing.T, db *sql.DB) int {
+	t.Helper()
+
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM comic_books").Scan(&n); err != nil {
+		t.Fatalf("Error counting books: %v", err)
+	}
+
+	return n
+}
+
+func TestMaintenance_Backup_Restore(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "solipull.db")
+	ctx := context.Background()
+
+	db := openDB(t, path)
+	m := NewMaintenance(db, path)
+
+	if _, err := db.Exec("INSERT INTO comic_books(id, title) VALUES ('1', 'Saga')"); err != nil {
+		t.Fatalf("Error inserting book: %v", err)
+	}
+
+	backup, err := m.Backup(ctx, "")
+	if err != nil {
+		t.Fatalf("Backup() error = %v", err)
+	}
+
+	if !strings.HasPrefix(backup, filepath.Join(dir, "backups", "solipull-")) {
+		t.Errorf("Backup() path = %s, want a file in the backups directory", backup)
+	}
+
+	if _, err := m.Backup(ctx, backup); err == nil {
+		t.Errorf("Backup() expected error for existing file")
+	}
+
+	if _, err := db.Exec("DELETE FROM comic_books"); err != nil {
+		t.Fatalf("Error deleting books: %v", err)
+	}
+
+	if err := m.Restore(ctx, backup); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	db = openDB(t, path)
+	t.Cleanup(func() { db.Close() })
+
+	if n := countBooks(t, db); n != 1 {
+		t.Errorf("Restore() got %d books, want 1", n)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "backups"))
+	if err != nil {
+		t.Fatalf("Error reading backups: %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Errorf("Restore() left %d backups, want the backup and a copy of the replaced database", len(entries))
+	}
+}
+
+func TestMaintenance_Restore_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "solipull.db")
+	ctx := context.Background()
+
+	db := openDB(t, path)
+	t.Cleanup(func() { db.Close() })
+
+	m := NewMaintenance(db, path)
+
+	newer := filepath.Join(dir, "newer.db")
+	if _, err := m.Backup(ctx, newer); err != nil {
+		t.Fatalf("Backup() error = %v", err)
+	}
+
+	other, err := sql.Open("sqlite", newer)
+	if err != nil {
+		t.Fatalf("Error opening backup: %v", err)
+	}
+	if _, err := other.Exec("INSERT INTO goose_db_version(version_id, is_applied) VALUES (9999, 1)"); err != nil {
+		t.Fatalf("Error bumping version: %v", err)
+	}
+	other.Close()
+
+	notADatabase := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(notADatabase, []byte("not a database"), 0o644); err != nil {
+		t.Fatalf("Error writing file: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{name: "missing file", src: filepath.Join(dir, "missing.db")},
+		{name: "not a database", src: notADatabase},
+		{name: "newer schema", src: newer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := m.Restore(ctx, tt.src); err == nil {
+				t.Errorf("Restore() expected error")
+			}
+		})
+	}
+
+	if n := countBooks(t, db); n != 0 {
+		t.Errorf("Restore() changed the database after a failed restore")
+	}
+}
+
+func TestMaintenance_Migrate_BacksUp(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "solipull.db")
+
+	latest, err := latestVersion()
+	if err != nil {
+		t.Fatalf("latestVersion() error = %v", err)
+	}
+
+	db, err := Open(path, "sqlite")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	if _, err := NewMaintenance(db, path).MigrateTo(context.Background(), latest-1); err != nil {
+		t.Fatalf("MigrateTo() error = %v", err)
+	}
+	db.Close()
+
+	db = openDB(t, path)
+	db.Close()
+
+	entries, err := os.ReadDir(filepath.Join(dir, "backups"))
+	if err != nil {
+		t.Fatalf("Error reading backups: %v", err)
+	}
+
+	if len(entries) != 1 {
+		t.Errorf("Migrate() made %d backups, want 1", len(entries))
+	}
+}
+
+func TestPruneMigrationBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "solipull.db")
+	backups := filepath.Join(dir, "backups")
+
+	if err := os.MkdirAll(backups, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	names := []string{"solipull-20261019-120000.db"}
+	for i := range keepMigrationBackups + 2 {
+		names = append(names, fmt.Sprintf("solipull-v%d-20261019-12000%d.db", i+1, i))
+	}
+
+	for i, name := range names {
+		f := filepath.Join(backups, name)
+		if err := os.WriteFile(f, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		modified := start.Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(f, modified, modified); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := pruneMigrationBackups(path); err != nil {
+		t.Fatalf("pruneMigrationBackups() error = %v", err)
+	}
+
+	entries, err := os.ReadDir(backups)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Name())
+	}
+
+	want := append([]string{names[0]}, names[3:]...)
+	slices.Sort(want)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("pruneMigrationBackups() left %v, want %v", got, want)
+	}
+}
+
+func TestMaintenance_MigrateTo(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "solipull.db")
+	ctx := context.Background()
+
+	db, err := Open(path, "sqlite")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	m := NewMaintenance(db, path)
+
+	latest, err := latestVersion()
+	if err != nil {
+		t.Fatalf("latestVersion() error = %v", err)
+	}
+
+	pending := func() int {
+		t.Helper()
+
+		migrations, err := m.Migrations(ctx)
+		if err != nil {
+			t.Fatalf("Migrations() error = %v", err)
+		}
+
+		n := 0
+		for _, mig := range migrations {
+			if !mig.Applied {
+				n++
+			}
+		}
+
+		return n
+	}
+
+	if n := pending(); n != int(latest) {
+		t.Errorf("Migrations() got %d pending on a new database, want %d", n, latest)
+	}
+
+	if backup, err := m.Migrate(ctx); err != nil || backup != "" {
+		t.Fatalf("Migrate() = %q, %v, want no backup of a new database", backup, err)
+	}
+
+	if n := pending(); n != 0 {
+		t.Errorf("Migrations() got %d pending after Migrate(), want 0", n)
+	}
+
+	backup, err := m.MigrateTo(ctx, latest-2)
+	if err != nil {
+		t.Fatalf("MigrateTo() error = %v", err)
+	}
+
+	if backup == "" {
+		t.Errorf("MigrateTo() made no backup before rolling back")
+	}
+
+	if n := pending(); n != 2 {
+		t.Errorf("Migrations() got %d pending after rolling back, want 2", n)
+	}
+
+	if _, err := m.MigrateTo(ctx, latest+1); err == nil {
+		t.Errorf("MigrateTo() expected error for unknown version")
+	}
+
+	if _, err := m.Migrate(ctx); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	if n := pending(); n != 0 {
+		t.Errorf("Migrations() got %d pending after migrating up again, want 0", n)
+	}
+}
+
+func TestMaintenance_Check(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "solipull.db")
+	ctx := context.Background()
+
+	db := openDB(t, path)
+	t.Cleanup(func() { db.Close() })
+
+	m := NewMaintenance(db, path)
+
+	report, err := m.Check(ctx)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	if !report.Healthy() {
+		t.Errorf("Check() got %+v for a new database, want a healthy report", report)
+	}
+
+	// Foreign keys are enforced, so the orphan is written the way older builds could leave it behind.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("Error getting connection: %v", err)
+	}
+	defer conn.Close()
+
+	for _, q := range []string{
+		"PRAGMA foreign_keys = OFF",
+		"INSERT INTO comic_books(id, title) VALUES ('1', 'Saga')",
+		"INSERT INTO creators(id, comic_book_id, role, name) VALUES ('a', '1', 'writer', 'Brian K. Vaughan')",
+		"INSERT INTO creators(id, comic_book_id, role, name) VALUES ('b', '2', 'artist', 'Fiona Staples')",
+	} {
+		if _, err := conn.ExecContext(ctx, q); err != nil {
+			t.Fatalf("Error inserting rows: %v", err)
+		}
+	}
+
+	report, err = m.Check(ctx)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	if report.OrphanCreators != 1 || report.UnlinkedCreators != 2 || len(report.ForeignKeys) != 0 {
+		t.Errorf("Check() got %+v, want 1 orphan and 2 unlinked creators", report)
+	}
+}
+
+func TestMaintenance_Vacuum(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "solipull.db")
+
+	db := openDB(t, path)
+	t.Cleanup(func() { db.Close() })
+
+	before, after, err := NewMaintenance(db, path).Vacuum(context.Background())
+	if err != nil {
+		t.Fatalf("Vacuum() error = %v", err)
+	}
+
+	if before == 0 || after == 0 || after > before {
+		t.Errorf("Vacuum() got %d -> %d bytes", before, after)
+	}
+}
+
+func TestOpen_Pragmas(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "solipull.db")
+
+	db := openDB(t, path)
+	t.Cleanup(func() { db.Close() })
+
+	tests := []struct {
+		pragma string
+		want   string
+	}{
+		{pragma: "foreign_keys", want: "1"},
+		{pragma: "journal_mode", want: "wal"},
+		{pragma: "busy_timeout", want: "5000"},
+		{pragma: "synchronous", want: "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pragma, func(t *testing.T) {
+			var got string
+			if err := db.QueryRow("PRAGMA " + tt.pragma).Scan(&got); err != nil {
+				t.Fatalf("Error reading pragma: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("PRAGMA %s = %s, want %s", tt.pragma, got, tt.want)
+			}
+		})
+	}
+
+	for _, q := range []string{
+		"INSERT INTO comic_books(id, title) VALUES ('1', 'Saga')",
+		"INSERT INTO creators(id, comic_book_id, role, name) VALUES ('a', '1', 'writer', 'Brian K. Vaughan')",
+		"DELETE FROM comic_books WHERE id = '1'",
+	} {
+		if _, err := db.Exec(q); err != nil {
+			t.Fatalf("Error running %q: %v", q, err)
+		}
+	}
+
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM creators").Scan(&n); err != nil {
+		t.Fatalf("Error counting creators: %v", err)
+	}
+
+	if n != 0 {
+		t.Errorf("deleting a book left %d creators behind", n)
+	}
+}
+
+func TestMigrate_DeletesOrphanedRows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "solipull.db")
+	ctx := context.Background()
+
+	db, err := Open(path, "sqlite")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	m := NewMaintenance(db, path)
+	if _, err := m.MigrateTo(ctx, 8); err != nil {
+		t.Fatalf("MigrateTo() error = %v", err)
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("Error getting connection: %v", err)
+	}
+
+	for _, q := range []string{
+		"PRAGMA foreign_keys = OFF",
+		"INSERT INTO comic_books(id, title) VALUES ('1', 'Saga')",
+		"INSERT INTO people(id, name) VALUES ('p1', 'Fiona Staples')",
+		"INSERT INTO person_aliases(alias, person_id) VALUES ('fiona staples', 'p1')",
+		"INSERT INTO creators(id, comic_book_id, role, name, person_id) VALUES ('a', '1', 'artist', 'Fiona Staples', 'p2')",
+		"INSERT INTO creators(id, comic_book_id, role, name) VALUES ('b', '2', 'writer', 'Brian K. Vaughan')",
+		"INSERT INTO follows(person_id, roles) VALUES ('p3', '')",
+	} {
+		if _, err := conn.ExecContext(ctx, q); err != nil {
+			t.Fatalf("Error running %q: %v", q, err)
+		}
+	}
+	conn.Close()
+
+	if _, err := m.Migrate(ctx); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	report, err := m.Check(ctx)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	// The credit of the removed person is unlinked, DatabaseService.Prepare links it again after migrating.
+	if report.UnlinkedCreators != 1 {
+		t.Errorf("Check() got %d unlinked creators after migrating, want 1", report.UnlinkedCreators)
+	}
+
+	report.UnlinkedCreators = 0
+	if !report.Healthy() {
+		t.Errorf("Check() got %+v after migrating, want a healthy report", report)
+	}
+
+	var personID sql.NullString
+	if err := db.QueryRow("SELECT person_id FROM creators WHERE id = 'a'").Scan(&personID); err != nil {
+		t.Fatalf("Error reading creator: %v", err)
+	}
+
+	if personID.Valid {
+		t.Errorf("Migrate() left creator linked to %q, want it unlinked", personID.String)
+	}
+}
@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/pressly/goose/v3"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// Migrations returns every migration embedded in the build, oldest first, and whether it is applied.
+func (m *Maintenance) Migrations(ctx context.Context) ([]models.Migration, error) {
+	p, err := m.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := p.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migration status: %v", err)
+	}
+
+	result := make([]models.Migration, 0, len(statuses))
+	for _, s := range statuses {
+		_, name, _ := strings.Cut(strings.TrimSuffix(filepath.Base(s.Source.Path), ".sql"), "_")
+
+		result = append(result, models.Migration{
+			Version:   s.Source.Version,
+			Name:      name,
+			Applied:   s.State == goose.StateApplied,
+			AppliedAt: s.AppliedAt,
+		})
+	}
+
+	return result, nil
+}
+
+// Migrate applies the pending migrations. See MigrateTo.
+func (m *Maintenance) Migrate(ctx context.Context) (string, error) {
+	latest, err := latestVersion()
+	if err != nil {
+		return "", err
+	}
+
+	return m.MigrateTo(ctx, latest)
+}
+
+// MigrateTo applies or rolls back migrations until the schema is at version. A database with data in it is backed
+// up first, so an experimental build or a rollback can not take the data with it. Only the last few of these backups
+// are kept. The path of the backup is returned, or an empty string when nothing changed.
+func (m *Maintenance) MigrateTo(ctx context.Context, version int64) (string, error) {
+	p, err := m.provider()
+	if err != nil {
+		return "", err
+	}
+
+	current, err := p.GetDBVersion(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to read schema version: %v", err)
+	}
+
+	latest, err := latestVersion()
+	if err != nil {
+		return "", err
+	}
+
+	if current > latest {
+		return "", fmt.Errorf("the database was migrated by a newer version of solipull (schema %d, this build supports %d)",
+			current, latest)
+	}
+
+	if version < 0 || version > latest {
+		return "", fmt.Errorf("unknown schema version %d, this build supports 0 to %d", version, latest)
+	}
+
+	if version == current {
+		return "", nil
+	}
+
+	var dst string
+	if current > 0 {
+		dst = backupPath(m.path, fmt.Sprintf("v%d", current))
+		if err := backup(ctx, m.db, dst); err != nil {
+			return "", err
+		}
+	}
+
+	if version > current {
+		_, err = p.UpTo(ctx, version)
+	} else {
+		_, err = p.DownTo(ctx, version)
+	}
+
+	if err != nil {
+		return dst, fmt.Errorf("failed to migrate from schema %d to %d: %v", current, version, err)
+	}
+
+	if err := pruneMigrationBackups(m.path); err != nil {
+		return dst, fmt.Errorf("failed to remove old backups: %v", err)
+	}
+
+	return dst, nil
+}
+
+func (m *Maintenance) provider() (*goose.Provider, error) {
+	fsys, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	return goose.NewProvider(goose.DialectSQLite3, m.db, fsys)
+}
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS crawl_queue (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    url TEXT NOT NULL,
+    request BLOB NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    created_at DATETIME,
+    updated_at DATETIME
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_crawl_queue_url ON crawl_queue(url);
+
+CREATE INDEX IF NOT EXISTS idx_crawl_queue_status ON crawl_queue(status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE crawl_queue;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE comic_books ADD COLUMN source TEXT NOT NULL DEFAULT 'comicreleases';
+
+ALTER TABLE crawl_queue ADD COLUMN source TEXT NOT NULL DEFAULT 'comicreleases';
+
+DROP INDEX IF EXISTS idx_crawl_queue_url;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_crawl_queue_source_url ON crawl_queue(source, url);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_crawl_queue_source_url;
+
+DELETE FROM crawl_queue WHERE source != 'comicreleases';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_crawl_queue_url ON crawl_queue(url);
+
+ALTER TABLE crawl_queue DROP COLUMN source;
+
+ALTER TABLE comic_books DROP COLUMN source;
+-- +goose StatementEnd
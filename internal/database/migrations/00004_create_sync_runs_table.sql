@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE comic_books ADD COLUMN url TEXT;
+
+CREATE TABLE IF NOT EXISTS sync_runs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    source TEXT NOT NULL,
+    started_at DATETIME NOT NULL,
+    finished_at DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS sync_page_quality (
+    sync_run_id INTEGER NOT NULL,
+    url TEXT NOT NULL,
+    books INTEGER NOT NULL,
+    dropped INTEGER NOT NULL,
+    missing_pages INTEGER NOT NULL,
+    missing_price INTEGER NOT NULL,
+    missing_creators INTEGER NOT NULL,
+    missing_release_date INTEGER NOT NULL,
+    PRIMARY KEY (sync_run_id, url),
+    FOREIGN KEY (sync_run_id) REFERENCES sync_runs(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE sync_page_quality;
+DROP TABLE sync_runs;
+ALTER TABLE comic_books DROP COLUMN url;
+-- +goose StatementEnd
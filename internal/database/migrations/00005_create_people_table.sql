@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS people (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at DATETIME
+);
+
+CREATE TABLE IF NOT EXISTS person_aliases (
+    alias TEXT PRIMARY KEY,
+    person_id TEXT NOT NULL,
+    FOREIGN KEY (person_id) REFERENCES people(id) ON DELETE CASCADE
+);
+
+ALTER TABLE creators ADD COLUMN person_id TEXT REFERENCES people(id);
+
+CREATE INDEX IF NOT EXISTS idx_creators_person ON creators(person_id);
+
+CREATE INDEX IF NOT EXISTS idx_person_aliases_person ON person_aliases(person_id);
+
+-- The existing credits are linked to people by DatabaseService.Prepare right after migrating, in Go, so their names
+-- are keyed by models.PersonKey like the credits saved later.
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_creators_person;
+ALTER TABLE creators DROP COLUMN person_id;
+DROP TABLE person_aliases;
+DROP TABLE people;
+-- +goose StatementEnd
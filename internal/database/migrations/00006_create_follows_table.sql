@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS follows (
+    person_id TEXT PRIMARY KEY,
+    roles TEXT NOT NULL DEFAULT '',
+    created_at DATETIME,
+    FOREIGN KEY (person_id) REFERENCES people(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE follows;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS series (
+    id TEXT PRIMARY KEY,
+    key TEXT NOT NULL UNIQUE,
+    title TEXT NOT NULL,
+    publisher TEXT,
+    volume INTEGER NOT NULL DEFAULT 0,
+    start_year INTEGER NOT NULL DEFAULT 0,
+    kind TEXT NOT NULL DEFAULT 'ongoing',
+    created_at DATETIME
+);
+
+ALTER TABLE comic_books ADD COLUMN series_id TEXT REFERENCES series(id);
+ALTER TABLE comic_books ADD COLUMN issue_number REAL;
+ALTER TABLE comic_books ADD COLUMN issue_suffix TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_comics_series ON comic_books(series_id, issue_number);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_comics_series;
+ALTER TABLE comic_books DROP COLUMN issue_suffix;
+ALTER TABLE comic_books DROP COLUMN issue_number;
+ALTER TABLE comic_books DROP COLUMN series_id;
+DROP TABLE series;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS collection_items (
+    comic_book_id TEXT PRIMARY KEY,
+    status TEXT NOT NULL CHECK (status IN ('wanted', 'ordered', 'owned', 'read')),
+    condition TEXT NOT NULL DEFAULT '',
+    purchase_price REAL,
+    purchase_date DATETIME,
+    location TEXT NOT NULL DEFAULT '',
+    created_at DATETIME,
+    updated_at DATETIME,
+    FOREIGN KEY (comic_book_id) REFERENCES comic_books(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_collection_status ON collection_items(status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE collection_items;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Foreign keys were not enforced before, so deleting a book or a person could leave rows referring to it behind.
+DELETE FROM creators WHERE comic_book_id NOT IN (SELECT id FROM comic_books);
+
+DELETE FROM collection_items WHERE comic_book_id NOT IN (SELECT id FROM comic_books);
+
+DELETE FROM person_aliases WHERE person_id NOT IN (SELECT id FROM people);
+
+DELETE FROM follows WHERE person_id NOT IN (SELECT id FROM people);
+
+DELETE FROM sync_page_quality WHERE sync_run_id NOT IN (SELECT id FROM sync_runs);
+
+-- Credits of a removed person are unlinked. DatabaseService.Prepare links them again right after migrating, through
+-- their alias when there is one.
+UPDATE creators SET person_id = NULL WHERE person_id IS NOT NULL AND person_id NOT IN (SELECT id FROM people);
+
+UPDATE comic_books SET series_id = NULL WHERE series_id NOT IN (SELECT id FROM series);
+-- +goose StatementEnd
+
+-- +goose Down
+-- Deleted rows can not be brought back, restore a backup instead.
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Collected editions were linked to the series of their singles, with the volume as issue number. Unlinking them
+-- lets DatabaseService.Prepare, which the commands that use the data run through requireDatabase, link them to a
+-- collected series of their own with LinkSeries right after this migration.
+UPDATE comic_books SET series_id = NULL, issue_number = NULL, issue_suffix = ''
+WHERE COALESCE(format, '') NOT IN ('', 'singles')
+   OR lower(title) LIKE '% tp' OR lower(title) LIKE '% tpb' OR lower(title) LIKE '% hc'
+   OR lower(title) LIKE '% gn' OR lower(title) LIKE '% sc' OR lower(title) LIKE '% omnibus';
+
+DELETE FROM series WHERE id NOT IN (SELECT series_id FROM comic_books WHERE series_id IS NOT NULL);
+-- +goose StatementEnd
+
+-- +goose Down
+-- The collected series are left in place, the old links put volumes among the issues.
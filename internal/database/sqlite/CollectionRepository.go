@@ -0,0 +1,191 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"strings"
+	"time"
+)
+
+type CollectionRepository struct {
+	db *sql.DB
+}
+
+func NewCollectionRepository(db *sql.DB) *CollectionRepository {
+	return &CollectionRepository{db}
+}
+
+// FindBooks returns the books matching title and issue regardless of case, of publisher when it is set, ordered by
+// release date.
+func (c *CollectionRepository) FindBooks(ctx context.Context, title, issue, publisher string) ([]models.ComicBook, error) {
+	stmt := `SELECT title, issue, pages, format, price, publisher, release_date, source, COALESCE(url, '')
+        FROM comic_books
+        WHERE lower(title) = ? AND lower(issue) = ? AND (? = '' OR publisher = ?)
+        ORDER BY release_date, publisher;`
+
+	publisher = strings.ToLower(publisher)
+	rows, err := c.db.QueryContext(ctx, stmt, normalize(title), normalize(issue), publisher, publisher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve comic books: %v", err)
+	}
+	defer rows.Close()
+
+	var cbs []models.ComicBook
+	for rows.Next() {
+		var cb models.ComicBook
+		err := rows.Scan(&cb.Title, &cb.Issue, &cb.Pages, &cb.Format, &cb.Price, &cb.Publisher, &cb.ReleaseDate,
+			&cb.Source, &cb.URL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve comic books: %v", err)
+		}
+
+		cbs = append(cbs, cb)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read comic books: %v", err)
+	}
+
+	return cbs, nil
+}
+
+// SaveItem adds a book to the collection or replaces the entry when it is already there.
+func (c *CollectionRepository) SaveItem(ctx context.Context, item models.CollectionItem) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := saveItem(ctx, tx, item); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func saveItem(ctx context.Context, tx *sql.Tx, item models.CollectionItem) error {
+	id, err := bookID(ctx, tx, item.ComicBook)
+	if err != nil {
+		return err
+	}
+
+	stmt := `INSERT INTO collection_items(comic_book_id, status, condition, purchase_price, purchase_date, location,
+            created_at, updated_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+        ON CONFLICT(comic_book_id) DO UPDATE SET status = excluded.status, condition = excluded.condition,
+            purchase_price = excluded.purchase_price, purchase_date = excluded.purchase_date,
+            location = excluded.location, updated_at = excluded.updated_at;`
+
+	var price sql.NullFloat64
+	if item.PurchasePrice > 0 {
+		price = sql.NullFloat64{Float64: item.PurchasePrice, Valid: true}
+	}
+
+	var date sql.NullTime
+	if !item.PurchaseDate.IsZero() {
+		date = sql.NullTime{Time: item.PurchaseDate, Valid: true}
+	}
+
+	now := time.Now()
+	_, err = tx.ExecContext(ctx, stmt, id, item.Status, item.Condition, price, date, item.Location, now, now)
+	if err != nil {
+		return fmt.Errorf("failed to store collection item: %v", err)
+	}
+
+	return nil
+}
+
+func (c *CollectionRepository) UpdateStatus(ctx context.Context, cb models.ComicBook, status string) error {
+	return c.update(ctx, cb, "UPDATE collection_items SET status = ?, updated_at = ? WHERE comic_book_id = ?",
+		status, time.Now())
+}
+
+func (c *CollectionRepository) RemoveItem(ctx context.Context, cb models.ComicBook) error {
+	return c.update(ctx, cb, "DELETE FROM collection_items WHERE comic_book_id = ?")
+}
+
+// ListItems returns the collection, or the items with status when it is set, ordered by release date.
+func (c *CollectionRepository) ListItems(ctx context.Context, status string) ([]models.CollectionItem, error) {
+	stmt := `SELECT cb.title, cb.issue, cb.pages, cb.format, cb.price, cb.publisher, cb.release_date, cb.source,
+            COALESCE(cb.url, ''), ci.status, ci.condition, ci.purchase_price, ci.purchase_date, ci.location
+        FROM collection_items AS ci
+        JOIN comic_books AS cb ON cb.id = ci.comic_book_id
+        WHERE ? = '' OR ci.status = ?
+        ORDER BY cb.release_date, cb.title, cb.issue;`
+
+	rows, err := c.db.QueryContext(ctx, stmt, status, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve collection: %v", err)
+	}
+	defer rows.Close()
+
+	var items []models.CollectionItem
+	for rows.Next() {
+		var item models.CollectionItem
+		var price sql.NullFloat64
+		var date sql.NullTime
+		err := rows.Scan(&item.Title, &item.Issue, &item.Pages, &item.Format, &item.Price, &item.Publisher,
+			&item.ReleaseDate, &item.Source, &item.URL, &item.Status, &item.Condition, &price, &date, &item.Location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve collection: %v", err)
+		}
+
+		item.PurchasePrice, item.PurchaseDate = price.Float64, date.Time
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read collection: %v", err)
+	}
+
+	return items, nil
+}
+
+// update runs stmt for the collection item of cb, the id of the book is appended to args.
+func (c *CollectionRepository) update(ctx context.Context, cb models.ComicBook, stmt string, args ...any) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	id, err := bookID(ctx, tx, cb)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, stmt, append(args, id)...)
+	if err != nil {
+		return fmt.Errorf("failed to update collection: %v", err)
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return models.ErrNotInCollection
+	}
+
+	return tx.Commit()
+}
+
+func bookID(ctx context.Context, tx *sql.Tx, cb models.ComicBook) (string, error) {
+	var id string
+
+	err := tx.QueryRowContext(ctx, `SELECT id FROM comic_books
+        WHERE title = ? AND issue = ? AND publisher = ? AND release_date = ?;`,
+		cb.Title, cb.Issue, cb.Publisher, cb.ReleaseDate).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("comic book %s #%s is not stored", cb.Title, cb.Issue)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve comic book: %v", err)
+	}
+
+	return id, nil
+}
+
+func normalize(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
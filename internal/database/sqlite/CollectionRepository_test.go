@@ -0,0 +1,100 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCollectionRepository(t *testing.T) {
+	books, _ := setupPersonRepository(t)
+	collection := NewCollectionRepository(books.db)
+	ctx := context.Background()
+
+	release := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	err := books.BulkSave(ctx, []models.ComicBook{
+		{Title: "Batman", Issue: "1", Publisher: "dc", Price: "$4.99", ReleaseDate: release},
+		{Title: "Batman", Issue: "1", Publisher: "image", ReleaseDate: release},
+		{Title: "Saga", Issue: "80", Publisher: "image", ReleaseDate: release.AddDate(0, 0, 7)},
+	})
+	if err != nil {
+		t.Fatalf("BulkSave() error = %v", err)
+	}
+
+	found, err := collection.FindBooks(ctx, "BATMAN", "1", "")
+	if err != nil {
+		t.Fatalf("FindBooks() error = %v", err)
+	}
+	if len(found) != 2 {
+		t.Fatalf("FindBooks() got %d books, want 2", len(found))
+	}
+
+	found, err = collection.FindBooks(ctx, "batman", "1", "DC")
+	if err != nil {
+		t.Fatalf("FindBooks() error = %v", err)
+	}
+	if len(found) != 1 || found[0].Price != "$4.99" {
+		t.Fatalf("FindBooks() got = %+v, want the dc book", found)
+	}
+
+	batman := models.CollectionItem{
+		ComicBook:     found[0],
+		Status:        models.StatusOwned,
+		Condition:     "NM",
+		PurchasePrice: 4.99,
+		PurchaseDate:  time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC),
+		Location:      "Box 1",
+	}
+	if err := collection.SaveItem(ctx, batman); err != nil {
+		t.Fatalf("SaveItem() error = %v", err)
+	}
+
+	saga, _ := collection.FindBooks(ctx, "saga", "80", "")
+	if err := collection.SaveItem(ctx, models.CollectionItem{ComicBook: saga[0], Status: models.StatusWanted}); err != nil {
+		t.Fatalf("SaveItem() error = %v", err)
+	}
+
+	if err := collection.UpdateStatus(ctx, batman.ComicBook, models.StatusRead); err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+	batman.Status = models.StatusRead
+
+	tests := []struct {
+		name   string
+		status string
+		want   []models.CollectionItem
+	}{
+		{
+			name: "all",
+			want: []models.CollectionItem{batman, {ComicBook: saga[0], Status: models.StatusWanted}},
+		},
+		{
+			name:   "by status",
+			status: models.StatusWanted,
+			want:   []models.CollectionItem{{ComicBook: saga[0], Status: models.StatusWanted}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := collection.ListItems(ctx, tt.status)
+			if err != nil {
+				t.Fatalf("ListItems() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListItems() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if err := collection.RemoveItem(ctx, saga[0]); err != nil {
+		t.Fatalf("RemoveItem() error = %v", err)
+	}
+
+	if err := collection.RemoveItem(ctx, saga[0]); !errors.Is(err, models.ErrNotInCollection) {
+		t.Errorf("RemoveItem() error = %v, want ErrNotInCollection", err)
+	}
+}
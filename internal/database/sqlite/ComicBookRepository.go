@@ -5,7 +5,8 @@ import (
 	"database/sql"
 	"fmt"
 	"github.com/google/uuid"
-	"slices"
+	"iter"
+	"strings"
 	"time"
 
 	"github.com/MikkelvtK/solipull/internal/models"
@@ -32,6 +33,9 @@ func NewComicBookRepository(db *sql.DB) *ComicBookRepository {
 	return &ComicBookRepository{db}
 }
 
+// BulkSave stores the books and their credits. A book that is already stored, by title, issue, publisher and release
+// date, keeps its pages, format and price but gets the description, source and url that were found and the new
+// credits. The source is the one that last saw the book, so it follows a book that moves to another source.
 func (c *ComicBookRepository) BulkSave(ctx context.Context, records []models.ComicBook) error {
 	tx, err := c.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -39,27 +43,45 @@ func (c *ComicBookRepository) BulkSave(ctx context.Context, records []models.Com
 	}
 	defer tx.Rollback()
 
+	if err := c.save(ctx, tx, records); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (c *ComicBookRepository) save(ctx context.Context, tx *sql.Tx, records []models.ComicBook) error {
 	comicStmt := `
-        INSERT INTO comic_books(id, title, issue, pages, format, price, publisher, release_date, created_at) 
-        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+        INSERT INTO comic_books(id, title, issue, pages, format, price, publisher, release_date, source, url, description,
+            created_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
         ON CONFLICT(title, issue, publisher, release_date)
-        DO UPDATE SET title=excluded.title
+        DO UPDATE SET title=excluded.title, description=COALESCE(NULLIF(excluded.description, ''), description),
+            source=COALESCE(NULLIF(excluded.source, ''), source), url=COALESCE(NULLIF(excluded.url, ''), url)
         RETURNING id;`
 
 	creatorStmt := `
-        INSERT INTO creators(id, comic_book_id, role, name, created_at)
-        VALUES (?, ?, ?, ?, ?);`
+        INSERT INTO creators(id, comic_book_id, role, name, person_id, created_at)
+        VALUES (?, ?, ?, ?, ?, ?);`
+
+	people := make(map[string]models.Person)
+	series := make(map[string]string)
 
 	for _, r := range records {
 		e := c.toComicBookEntity(r)
 		var dbID string
 
 		err := tx.QueryRowContext(ctx, comicStmt,
-			e.id, e.Title, e.Issue, e.Pages, e.Format, e.Price, e.Publisher, e.ReleaseDate, e.createdAt).Scan(&dbID)
+			e.id, e.Title, e.Issue, e.Pages, e.Format, e.Price, e.Publisher, e.ReleaseDate, e.Source, e.URL, e.Description,
+			e.createdAt).Scan(&dbID)
 		if err != nil {
 			return fmt.Errorf("failed to store comic book: %v", err)
 		}
 
+		if err := linkSeries(ctx, tx, series, dbID, r); err != nil {
+			return err
+		}
+
 		_, err = tx.ExecContext(ctx, "DELETE FROM creators WHERE comic_book_id = ?", dbID)
 		if err != nil {
 			return fmt.Errorf("failed to delete creators: %v", err)
@@ -68,65 +90,210 @@ func (c *ComicBookRepository) BulkSave(ctx context.Context, records []models.Com
 		for _, creator := range r.Creators {
 			ce := c.toCreatorEntity(dbID, creator)
 
-			if _, err := tx.ExecContext(ctx, creatorStmt, ce.id, ce.comicBookId, ce.Role, ce.Name, ce.createdAt); err != nil {
+			p, err := resolvePerson(ctx, tx, people, ce.Name)
+			if err != nil {
+				return err
+			}
+
+			var personID sql.NullString
+			if p.ID != "" {
+				ce.Name = p.Name
+				personID = sql.NullString{String: p.ID, Valid: true}
+			}
+
+			if _, err := tx.ExecContext(ctx, creatorStmt, ce.id, ce.comicBookId, ce.Role, ce.Name, personID, ce.createdAt); err != nil {
 				return fmt.Errorf("failed to store creators: %v", err)
 			}
 		}
 	}
 
-	return tx.Commit()
+	return nil
 }
 
 func (c *ComicBookRepository) GetAll(ctx context.Context) ([]models.ComicBook, error) {
-	tx, err := c.db.BeginTx(ctx, nil)
-	if err != nil {
-		return nil, err
+	var cbs []models.ComicBook
+	for cb, err := range c.Books(ctx, models.BookQuery{}) {
+		if err != nil {
+			return nil, err
+		}
+
+		cbs = append(cbs, cb)
 	}
-	defer tx.Rollback()
 
-	stmt := `SELECT cb.id, cb.title, cb.issue, cb.pages, cb.format, cb.price, cb.publisher, cb.release_date,
-            cr.role, cr.name
-        FROM comic_books AS cb
-        LEFT JOIN creators AS cr
-        ON cb.id = cr.comic_book_id;`
+	return cbs, nil
+}
 
-	rows, err := tx.QueryContext(ctx, stmt)
-	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve comic books: %v", err)
-	}
-	defer rows.Close()
+// bookOrders are the ORDER BY clauses of the book sorts. Issues are ordered on their number before their text, so
+// #2 comes before #10, and the id makes the order total.
+var bookOrders = map[models.BookSort]string{
+	models.SortByRelease: "cb.release_date, cb.publisher, cb.title COLLATE NOCASE, " + issueOrder + ", cb.id",
+	models.SortByTitle:   "cb.title COLLATE NOCASE, " + issueOrder + ", cb.release_date, cb.publisher, cb.id",
+	models.SortByPublisher: "cb.publisher, cb.release_date, cb.title COLLATE NOCASE, " + issueOrder +
+		", cb.id",
+}
+
+const issueOrder = "cb.issue_number IS NULL, cb.issue_number, cb.issue_suffix, cb.issue"
 
-	cbs := make(map[string]*comicBookEntity)
+// Books streams the books matching q with their creators. Only one book is held in memory at a time, and the rows
+// are released when the iteration stops.
+func (c *ComicBookRepository) Books(ctx context.Context, q models.BookQuery) iter.Seq2[models.ComicBook, error] {
+	return func(yield func(models.ComicBook, error) bool) {
+		order, ok := bookOrders[q.Sort]
+		if q.Sort == "" {
+			order, ok = bookOrders[models.SortByRelease], true
+		}
+
+		if !ok {
+			yield(models.ComicBook{}, fmt.Errorf("unknown sort %q", q.Sort))
+			return
+		}
 
-	for rows.Next() {
-		var cb comicBookEntity
-		var role, name sql.NullString
-		err := rows.Scan(&cb.id, &cb.Title, &cb.Issue, &cb.Pages, &cb.Format, &cb.Price, &cb.Publisher,
-			&cb.ReleaseDate, &role, &name)
+		where, args := bookFilter(q)
+
+		limit := q.Limit
+		if limit <= 0 {
+			limit = -1
+		}
+
+		// The page of books is selected first, so the limit counts books instead of creator rows.
+		stmt := `SELECT cb.id, cb.title, cb.issue, cb.pages, cb.format, cb.price, cb.publisher, cb.release_date,
+                cb.source, COALESCE(cb.url, ''), cb.description, cr.role, cr.name
+            FROM (SELECT * FROM comic_books AS cb ` + where + ` ORDER BY ` + order + ` LIMIT ? OFFSET ?) AS cb
+            LEFT JOIN creators AS cr
+            ON cb.id = cr.comic_book_id
+            ORDER BY ` + order + `, cr.rowid;`
+
+		rows, err := c.db.QueryContext(ctx, stmt, append(args, limit, max(q.Offset, 0))...)
 		if err != nil {
-			return nil, fmt.Errorf("failed to retrieve comic books: %v", err)
+			yield(models.ComicBook{}, fmt.Errorf("failed to retrieve comic books: %v", err))
+			return
 		}
+		defer rows.Close()
+
+		var current *comicBookEntity
 
-		if _, ok := cbs[cb.id]; !ok {
-			cbs[cb.id] = &cb
+		for rows.Next() {
+			var cb comicBookEntity
+			var role, name sql.NullString
+			err := rows.Scan(&cb.id, &cb.Title, &cb.Issue, &cb.Pages, &cb.Format, &cb.Price, &cb.Publisher,
+				&cb.ReleaseDate, &cb.Source, &cb.URL, &cb.Description, &role, &name)
+			if err != nil {
+				yield(models.ComicBook{}, fmt.Errorf("failed to retrieve comic books: %v", err))
+				return
+			}
+
+			if current != nil && current.id != cb.id {
+				if !yield(current.ComicBook, nil) {
+					return
+				}
+				current = nil
+			}
+
+			if current == nil {
+				current = &cb
+			}
+
+			if role.Valid || name.Valid {
+				current.Creators = append(current.Creators, models.Creator{Name: name.String, Role: role.String})
+			}
 		}
 
-		if role.Valid || name.Valid {
-			cbs[cb.id].Creators = append(cbs[cb.id].Creators, models.Creator{Name: name.String, Role: role.String})
+		if err := rows.Err(); err != nil {
+			yield(models.ComicBook{}, fmt.Errorf("failed to read comic books: %v", err))
+			return
+		}
+
+		if current != nil {
+			yield(current.ComicBook, nil)
 		}
 	}
+}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("failed to read comic books: %v", err)
+func bookFilter(q models.BookQuery) (string, []any) {
+	var conds []string
+	var args []any
+
+	if len(q.Publishers) > 0 {
+		conds = append(conds, "lower(cb.publisher) IN ("+placeholders(len(q.Publishers))+")")
+		for _, p := range q.Publishers {
+			args = append(args, strings.ToLower(p))
+		}
 	}
 
-	return slices.Collect(func(yield func(book models.ComicBook) bool) {
-		for _, v := range cbs {
-			if !yield(v.ComicBook) {
-				return
-			}
+	// Release dates are stored as "2006-01-02 15:04:05 -0700 MST", which SQLite's date functions can not read.
+	if len(q.Months) > 0 {
+		conds = append(conds, "CAST(substr(cb.release_date, 6, 2) AS INTEGER) IN ("+placeholders(len(q.Months))+")")
+		for _, m := range q.Months {
+			args = append(args, int(m))
+		}
+	}
+
+	if q.Search != "" {
+		pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(q.Search) + "%"
+		conds = append(conds, `(cb.title LIKE ? ESCAPE '\' OR EXISTS (
+            SELECT 1 FROM creators WHERE comic_book_id = cb.id AND name LIKE ? ESCAPE '\'))`)
+		args = append(args, pattern, pattern)
+	}
+
+	if len(conds) == 0 {
+		return "", nil
+	}
+
+	return "WHERE " + strings.Join(conds, " AND "), args
+}
+
+// Prune deletes the books released before the given date, together with their credits and the series left without
+// books. Books in the collection, which holds the pull list, are kept, and so are books without a release date: their
+// date failed to parse, which says nothing about their age. On a dry run nothing is deleted and the summary tells
+// what would be.
+func (c *ComicBookRepository) Prune(ctx context.Context, before time.Time, dryRun bool) (models.PruneSummary, error) {
+	s := models.PruneSummary{Before: before}
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return s, err
+	}
+	defer tx.Rollback()
+
+	// Every query takes the zero date and before, in that order.
+	args := []any{time.Time{}, before}
+	prunable := `SELECT id FROM comic_books
+        WHERE release_date > ? AND release_date < ? AND id NOT IN (SELECT comic_book_id FROM collection_items)`
+
+	counts := []struct {
+		dst   *int
+		query string
+	}{
+		{&s.Books, "SELECT COUNT(*) FROM comic_books WHERE id IN (" + prunable + ")"},
+		{&s.Creators, "SELECT COUNT(*) FROM creators WHERE comic_book_id IN (" + prunable + ")"},
+		{&s.Kept, `SELECT COUNT(*) FROM comic_books
+            WHERE release_date > ? AND release_date < ? AND id IN (SELECT comic_book_id FROM collection_items)`},
+		{&s.Series, `SELECT COUNT(*) FROM series WHERE id NOT IN (
+            SELECT series_id FROM comic_books WHERE series_id IS NOT NULL AND id NOT IN (` + prunable + `))`},
+	}
+
+	for _, q := range counts {
+		if err := tx.QueryRowContext(ctx, q.query, args...).Scan(q.dst); err != nil {
+			return s, fmt.Errorf("failed to count books to prune: %v", err)
 		}
-	}), nil
+	}
+
+	if dryRun || (s.Books == 0 && s.Series == 0) {
+		return s, nil
+	}
+
+	// Credits are deleted by the foreign key cascade.
+	if _, err := tx.ExecContext(ctx, "DELETE FROM comic_books WHERE id IN ("+prunable+")", args...); err != nil {
+		return s, fmt.Errorf("failed to prune books: %v", err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"DELETE FROM series WHERE id NOT IN (SELECT series_id FROM comic_books WHERE series_id IS NOT NULL)")
+	if err != nil {
+		return s, fmt.Errorf("failed to prune series: %v", err)
+	}
+
+	return s, tx.Commit()
 }
 
 func (c *ComicBookRepository) toComicBookEntity(cb models.ComicBook) comicBookEntity {
@@ -145,3 +312,8 @@ func (c *ComicBookRepository) toCreatorEntity(cbUUID string, creator models.Crea
 		Creator:     creator,
 	}
 }
+
+// placeholders returns n comma separated query parameters for an IN clause.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
@@ -19,7 +19,14 @@ func setupDB(t *testing.T) (*sql.DB, func()) {
 
 	var path = "./test/test_db.db"
 
-	db := database.MustOpen(path, "sqlite")
+	db, err := database.Open(path, "sqlite")
+	if err != nil {
+		t.Fatalf("Error opening db: %v", err)
+	}
+
+	if _, err := database.NewMaintenance(db, path).Migrate(context.Background()); err != nil {
+		t.Fatalf("Error migrating db: %v", err)
+	}
 
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		t.Fatalf("sqlite db not created")
@@ -118,6 +125,7 @@ func TestComicBookRepository_GetAll_BulkSave(t *testing.T) {
 						},
 						Publisher:   "dc",
 						ReleaseDate: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+						Description: "description",
 					},
 				},
 			},
@@ -164,6 +172,37 @@ func TestComicBookRepository_GetAll_BulkSave(t *testing.T) {
 	}
 }
 
+func TestComicBookRepository_BulkSave_Updates(t *testing.T) {
+	books, _ := setupPersonRepository(t)
+	ctx := context.Background()
+
+	release := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	stored := models.ComicBook{Title: "Batman", Issue: "1", Pages: "32", Format: "singles", Price: "$4.99",
+		Publisher: "dc", ReleaseDate: release, Description: "description", Source: "comicreleases",
+		URL: "https://comicreleases.com/batman"}
+	if err := books.BulkSave(ctx, []models.ComicBook{stored}); err != nil {
+		t.Fatalf("BulkSave() error = %v", err)
+	}
+
+	updated := models.ComicBook{Title: "Batman", Issue: "1", Price: "$5.99", Publisher: "dc", ReleaseDate: release,
+		Description: "new description", Source: "dc"}
+	if err := books.BulkSave(ctx, []models.ComicBook{updated}); err != nil {
+		t.Fatalf("BulkSave() error = %v", err)
+	}
+
+	got, err := books.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+
+	want := stored
+	want.Description = "new description"
+	want.Source = "dc"
+	if len(got) != 1 || !reflect.DeepEqual(got[0], want) {
+		t.Errorf("BulkSave() stored %+v, want %+v", got, want)
+	}
+}
+
 func TestNewComicBookRepository(t *testing.T) {
 	db, teardown := setupDB(t)
 	t.Cleanup(func() {
@@ -198,3 +237,193 @@ func TestNewComicBookRepository(t *testing.T) {
 		})
 	}
 }
+
+func TestComicBookRepository_Prune(t *testing.T) {
+	books, _ := setupPersonRepository(t)
+	collection := NewCollectionRepository(books.db)
+	ctx := context.Background()
+
+	old := time.Date(2023, 5, 3, 0, 0, 0, 0, time.UTC)
+	cutoff := time.Date(2024, 10, 19, 0, 0, 0, 0, time.UTC)
+	writer := models.Creator{Name: "Tom King", Role: models.RoleWriter}
+
+	issue := func(title, issue string, release time.Time) models.ComicBook {
+		cb := releasedBook(title, release, writer)
+		cb.Issue = issue
+		return cb
+	}
+
+	err := books.BulkSave(ctx, []models.ComicBook{
+		issue("Mister Miracle", "1", old),
+		issue("Mister Miracle", "2", old),
+		issue("Strange Adventures", "1", old),
+		issue("Batman", "150", cutoff.AddDate(0, 1, 0)),
+		issue("Nightwing", "1", time.Time{}),
+	})
+	if err != nil {
+		t.Fatalf("BulkSave() error = %v", err)
+	}
+
+	wanted, err := collection.FindBooks(ctx, "Mister Miracle", "2", "")
+	if err != nil || len(wanted) != 1 {
+		t.Fatalf("FindBooks() got %d books, error = %v", len(wanted), err)
+	}
+
+	if err := collection.SaveItem(ctx, models.CollectionItem{ComicBook: wanted[0], Status: models.StatusWanted}); err != nil {
+		t.Fatalf("SaveItem() error = %v", err)
+	}
+
+	want := models.PruneSummary{Before: cutoff, Books: 2, Creators: 2, Series: 1, Kept: 1}
+
+	dry, err := books.Prune(ctx, cutoff, true)
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(dry, want) {
+		t.Errorf("Prune() dry run got = %+v, want %+v", dry, want)
+	}
+
+	if all, _ := books.GetAll(ctx); len(all) != 5 {
+		t.Errorf("Prune() dry run deleted books, %d left", len(all))
+	}
+
+	got, err := books.Prune(ctx, cutoff, false)
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Prune() got = %+v, want %+v", got, want)
+	}
+
+	all, err := books.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+
+	if got := slices.Sorted(slices.Values(titles(all))); !reflect.DeepEqual(got, []string{"Batman", "Mister Miracle", "Nightwing"}) {
+		t.Errorf("Prune() left %v", got)
+	}
+
+	var creators int
+	if err := books.db.QueryRow("SELECT COUNT(*) FROM creators").Scan(&creators); err != nil {
+		t.Fatalf("Error counting creators: %v", err)
+	}
+
+	if creators != 3 {
+		t.Errorf("Prune() left %d creators, want 3", creators)
+	}
+}
+
+func TestComicBookRepository_Books(t *testing.T) {
+	books, _ := setupPersonRepository(t)
+	ctx := context.Background()
+
+	march := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	april := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	err := books.BulkSave(ctx, []models.ComicBook{
+		{Title: "Saga", Issue: "10", Publisher: "image", ReleaseDate: march},
+		{Title: "Saga", Issue: "9", Publisher: "image", ReleaseDate: march},
+		{Title: "Batman", Issue: "1", Publisher: "dc", ReleaseDate: april,
+			Creators: []models.Creator{{Name: "Tom King", Role: models.RoleWriter}}},
+		{Title: "Absolute Batman", Issue: "2", Publisher: "dc", ReleaseDate: march,
+			Creators: []models.Creator{
+				{Name: "Scott Snyder", Role: models.RoleWriter},
+				{Name: "Nick Dragotta", Role: models.RoleArtist},
+			}},
+	})
+	if err != nil {
+		t.Fatalf("BulkSave() error = %v", err)
+	}
+
+	label := func(cbs []models.ComicBook) []string {
+		var got []string
+		for _, cb := range cbs {
+			got = append(got, cb.Title+" #"+cb.Issue)
+		}
+
+		return got
+	}
+
+	tests := []struct {
+		name string
+		q    models.BookQuery
+		want []string
+	}{
+		{
+			name: "orders on release date, publisher, title and issue number",
+			want: []string{"Absolute Batman #2", "Saga #9", "Saga #10", "Batman #1"},
+		},
+		{
+			name: "orders on title",
+			q:    models.BookQuery{Sort: models.SortByTitle},
+			want: []string{"Absolute Batman #2", "Batman #1", "Saga #9", "Saga #10"},
+		},
+		{
+			name: "filters on publisher and month",
+			q:    models.BookQuery{Publishers: []string{"DC"}, Months: []time.Month{time.March}},
+			want: []string{"Absolute Batman #2"},
+		},
+		{
+			name: "searches titles and creators",
+			q:    models.BookQuery{Search: "king"},
+			want: []string{"Batman #1"},
+		},
+		{
+			name: "pages with limit and offset",
+			q:    models.BookQuery{Limit: 2, Offset: 1},
+			want: []string{"Saga #9", "Saga #10"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []models.ComicBook
+			for cb, err := range books.Books(ctx, tt.q) {
+				if err != nil {
+					t.Fatalf("Books() error = %v", err)
+				}
+
+				got = append(got, cb)
+			}
+
+			if !reflect.DeepEqual(label(got), tt.want) {
+				t.Errorf("Books() got = %v, want %v", label(got), tt.want)
+			}
+		})
+	}
+
+	for cb, err := range books.Books(ctx, models.BookQuery{Search: "absolute"}) {
+		if err != nil {
+			t.Fatalf("Books() error = %v", err)
+		}
+
+		if got := creatorList(cb.Creators); got != "Scott Snyder, Nick Dragotta" {
+			t.Errorf("Books() creators = %s, want them in stored order", got)
+		}
+	}
+
+	for range books.Books(ctx, models.BookQuery{}) {
+		break
+	}
+
+	if _, err := books.GetAll(ctx); err != nil {
+		t.Errorf("GetAll() after stopping early error = %v", err)
+	}
+
+	for _, err := range books.Books(ctx, models.BookQuery{Sort: "price"}) {
+		if err == nil {
+			t.Errorf("Books() expected error for unknown sort")
+		}
+	}
+}
+
+func creatorList(creators []models.Creator) string {
+	names := make([]string, 0, len(creators))
+	for _, cr := range creators {
+		names = append(names, cr.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
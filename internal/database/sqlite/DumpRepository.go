@@ -0,0 +1,49 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"github.com/MikkelvtK/solipull/internal/models"
+)
+
+type DumpRepository struct {
+	db *sql.DB
+}
+
+func NewDumpRepository(db *sql.DB) *DumpRepository {
+	return &DumpRepository{db}
+}
+
+// LoadDump merges dump into the database in a single transaction, so a dump that fails to load leaves nothing behind.
+// People are stored before the books so their credits resolve to the same people as in the dumped database.
+func (d *DumpRepository) LoadDump(ctx context.Context, dump models.Dump) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, p := range dump.People {
+		if err := savePerson(ctx, tx, p); err != nil {
+			return err
+		}
+	}
+
+	if err := NewComicBookRepository(d.db).save(ctx, tx, dump.ComicBooks); err != nil {
+		return err
+	}
+
+	for _, f := range dump.Follows {
+		if err := follow(ctx, tx, f.Name, f.Roles); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range dump.Collection {
+		if err := saveItem(ctx, tx, item); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
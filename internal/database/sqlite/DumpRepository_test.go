@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"context"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"testing"
+)
+
+func TestDumpRepository_LoadDump(t *testing.T) {
+	books, people := setupPersonRepository(t)
+	dumps := NewDumpRepository(books.db)
+	ctx := context.Background()
+
+	saga := book("Saga", models.Creator{Name: "Fiona Staples", Role: models.RoleArtist})
+	dump := models.Dump{
+		Version:    models.DumpVersion,
+		ComicBooks: []models.ComicBook{saga},
+		People:     []models.Person{{Name: "Fiona Staples"}},
+		Follows:    []models.Follow{{Name: "Fiona Staples", Roles: []string{models.RoleArtist}}},
+		Collection: []models.CollectionItem{{ComicBook: book("Batman"), Status: models.StatusOwned}},
+	}
+
+	if err := dumps.LoadDump(ctx, dump); err == nil {
+		t.Fatalf("LoadDump() expected error for a collection item without a book")
+	}
+
+	cbs, err := books.GetAll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ps, err := people.ListPeople(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cbs) != 0 || len(ps) != 0 {
+		t.Errorf("LoadDump() left %d books and %d people after failing, want none", len(cbs), len(ps))
+	}
+
+	dump.Collection[0].ComicBook = saga
+	if err := dumps.LoadDump(ctx, dump); err != nil {
+		t.Fatalf("LoadDump() error = %v", err)
+	}
+
+	items, err := NewCollectionRepository(books.db).ListItems(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 1 || items[0].Title != "Saga" {
+		t.Errorf("LoadDump() collection = %+v, want Saga", items)
+	}
+}
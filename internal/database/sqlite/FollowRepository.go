@@ -0,0 +1,152 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"strings"
+	"time"
+)
+
+type FollowRepository struct {
+	db *sql.DB
+}
+
+func NewFollowRepository(db *sql.DB) *FollowRepository {
+	return &FollowRepository{db}
+}
+
+// Follow starts following the creator known as name, replacing the roles when the creator is already followed.
+func (f *FollowRepository) Follow(ctx context.Context, name string, roles []string) error {
+	tx, err := f.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := follow(ctx, tx, name, roles); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func follow(ctx context.Context, tx *sql.Tx, name string, roles []string) error {
+	person, err := findPerson(ctx, tx, name)
+	if err != nil {
+		return err
+	}
+
+	stmt := `INSERT INTO follows(person_id, roles, created_at) VALUES (?, ?, ?)
+        ON CONFLICT(person_id) DO UPDATE SET roles = excluded.roles;`
+
+	if _, err := tx.ExecContext(ctx, stmt, person.ID, strings.Join(roles, ","), time.Now()); err != nil {
+		return fmt.Errorf("failed to store follow: %v", err)
+	}
+
+	return nil
+}
+
+func (f *FollowRepository) Unfollow(ctx context.Context, name string) error {
+	tx, err := f.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	person, err := findPerson(ctx, tx, name)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM follows WHERE person_id = ?", person.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete follow: %v", err)
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("%s is not followed", person.Name)
+	}
+
+	return tx.Commit()
+}
+
+func (f *FollowRepository) ListFollows(ctx context.Context) ([]models.Follow, error) {
+	rows, err := f.db.QueryContext(ctx, `SELECT p.name, f.roles FROM follows AS f
+        JOIN people AS p ON p.id = f.person_id
+        ORDER BY p.name COLLATE NOCASE;`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve follows: %v", err)
+	}
+	defer rows.Close()
+
+	var follows []models.Follow
+
+	for rows.Next() {
+		var follow models.Follow
+		var roles string
+		if err := rows.Scan(&follow.Name, &roles); err != nil {
+			return nil, fmt.Errorf("failed to retrieve follows: %v", err)
+		}
+
+		if roles != "" {
+			follow.Roles = strings.Split(roles, ",")
+		}
+
+		follows = append(follows, follow)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read follows: %v", err)
+	}
+
+	return follows, nil
+}
+
+// FollowedBooks returns the books matching q ordered by release date. The creators of each book are limited to the
+// credits of followed creators.
+func (f *FollowRepository) FollowedBooks(ctx context.Context, q models.FollowedBooksQuery) ([]models.ComicBook, error) {
+	stmt := `SELECT cb.id, cb.title, cb.issue, cb.pages, cb.format, cb.price, cb.publisher, cb.release_date,
+            cb.source, COALESCE(cb.url, ''), cr.role, cr.name
+        FROM comic_books AS cb
+        JOIN creators AS cr ON cr.comic_book_id = cb.id
+        JOIN follows AS f ON f.person_id = cr.person_id
+        WHERE (f.roles = '' OR instr(',' || f.roles || ',', ',' || cr.role || ',') > 0)
+            AND cb.release_date >= ?
+            AND (? OR cb.release_date < ?)
+            AND cb.created_at >= ?
+        ORDER BY cb.release_date, cb.title, cb.issue, cb.id, cr.name, cr.role;`
+
+	rows, err := f.db.QueryContext(ctx, stmt, q.From.UTC(), q.To.IsZero(), q.To.UTC(), q.AddedSince)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve followed books: %v", err)
+	}
+	defer rows.Close()
+
+	var cbs []models.ComicBook
+	var lastID string
+
+	for rows.Next() {
+		var cb comicBookEntity
+		var cr models.Creator
+		err := rows.Scan(&cb.id, &cb.Title, &cb.Issue, &cb.Pages, &cb.Format, &cb.Price, &cb.Publisher,
+			&cb.ReleaseDate, &cb.Source, &cb.URL, &cr.Role, &cr.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve followed books: %v", err)
+		}
+
+		if cb.id != lastID {
+			cbs = append(cbs, cb.ComicBook)
+			lastID = cb.id
+		}
+
+		cbs[len(cbs)-1].Creators = append(cbs[len(cbs)-1].Creators, cr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read followed books: %v", err)
+	}
+
+	return cbs, nil
+}
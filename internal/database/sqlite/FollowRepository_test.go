@@ -0,0 +1,153 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func releasedBook(title string, release time.Time, creators ...models.Creator) models.ComicBook {
+	cb := book(title, creators...)
+	cb.ReleaseDate = release
+	return cb
+}
+
+func titles(cbs []models.ComicBook) []string {
+	var got []string
+	for _, cb := range cbs {
+		got = append(got, cb.Title)
+	}
+
+	return got
+}
+
+func TestFollowRepository_FollowedBooks(t *testing.T) {
+	books, _ := setupPersonRepository(t)
+	follows := NewFollowRepository(books.db)
+	ctx := context.Background()
+
+	march := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	err := books.BulkSave(ctx, []models.ComicBook{
+		releasedBook("Saga", march.AddDate(0, 0, 14),
+			models.Creator{Name: "Brian K. Vaughan", Role: models.RoleWriter},
+			models.Creator{Name: "Fiona Staples", Role: models.RoleArtist},
+			models.Creator{Name: "Fiona Staples", Role: models.RoleCoverArtist}),
+		releasedBook("Batman", march,
+			models.Creator{Name: "Tom King", Role: models.RoleWriter},
+			models.Creator{Name: "Fiona Staples", Role: models.RoleCoverArtist}),
+		releasedBook("Paper Girls", march.AddDate(0, 1, 0),
+			models.Creator{Name: "Brian K. Vaughan", Role: models.RoleWriter}),
+	})
+	if err != nil {
+		t.Fatalf("BulkSave() error = %v", err)
+	}
+
+	if err := follows.Follow(ctx, "brian k vaughan", nil); err != nil {
+		t.Fatalf("Follow() error = %v", err)
+	}
+	if err := follows.Follow(ctx, "Fiona Staples", []string{models.RoleArtist}); err != nil {
+		t.Fatalf("Follow() error = %v", err)
+	}
+	if err := follows.Follow(ctx, "Jonathan Hickman", nil); !errors.Is(err, models.ErrPersonNotFound) {
+		t.Errorf("Follow() error = %v, want ErrPersonNotFound", err)
+	}
+
+	tests := []struct {
+		name       string
+		q          models.FollowedBooksQuery
+		wantTitles []string
+	}{
+		{
+			name:       "open ended",
+			q:          models.FollowedBooksQuery{},
+			wantTitles: []string{"Saga", "Paper Girls"},
+		},
+		{
+			name:       "date range",
+			q:          models.FollowedBooksQuery{From: march, To: march.AddDate(0, 1, 0)},
+			wantTitles: []string{"Saga"},
+		},
+		{
+			name:       "added since before the save",
+			q:          models.FollowedBooksQuery{AddedSince: time.Now().Add(-time.Hour)},
+			wantTitles: []string{"Saga", "Paper Girls"},
+		},
+		{
+			name:       "added since after the save",
+			q:          models.FollowedBooksQuery{AddedSince: time.Now().Add(time.Hour)},
+			wantTitles: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := follows.FollowedBooks(ctx, tt.q)
+			if err != nil {
+				t.Fatalf("FollowedBooks() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(titles(got), tt.wantTitles) {
+				t.Errorf("FollowedBooks() got = %v, want %v", titles(got), tt.wantTitles)
+			}
+		})
+	}
+
+	got, err := follows.FollowedBooks(ctx, models.FollowedBooksQuery{From: march, To: march.AddDate(0, 1, 0)})
+	if err != nil {
+		t.Fatalf("FollowedBooks() error = %v", err)
+	}
+
+	wantCreators := []models.Creator{
+		{Name: "Brian K. Vaughan", Role: models.RoleWriter},
+		{Name: "Fiona Staples", Role: models.RoleArtist},
+	}
+	if !reflect.DeepEqual(got[0].Creators, wantCreators) {
+		t.Errorf("FollowedBooks() creators = %+v, want %+v", got[0].Creators, wantCreators)
+	}
+}
+
+func TestFollowRepository_ListFollows_Unfollow(t *testing.T) {
+	books, people := setupPersonRepository(t)
+	follows := NewFollowRepository(books.db)
+	ctx := context.Background()
+
+	err := books.BulkSave(ctx, []models.ComicBook{
+		book("Saga", models.Creator{Name: "Brian K. Vaughan", Role: models.RoleWriter}),
+		book("Y The Last Man", models.Creator{Name: "Brian Vaughan", Role: models.RoleWriter}),
+	})
+	if err != nil {
+		t.Fatalf("BulkSave() error = %v", err)
+	}
+
+	if err := follows.Follow(ctx, "Brian Vaughan", []string{models.RoleWriter, models.RoleArtist}); err != nil {
+		t.Fatalf("Follow() error = %v", err)
+	}
+
+	if err := people.MergePeople(ctx, "Brian Vaughan", "Brian K. Vaughan"); err != nil {
+		t.Fatalf("MergePeople() error = %v", err)
+	}
+
+	got, err := follows.ListFollows(ctx)
+	if err != nil {
+		t.Fatalf("ListFollows() error = %v", err)
+	}
+
+	want := []models.Follow{{Name: "Brian K. Vaughan", Roles: []string{models.RoleWriter, models.RoleArtist}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListFollows() got = %+v, want %+v", got, want)
+	}
+
+	if err := follows.Unfollow(ctx, "Brian K. Vaughan"); err != nil {
+		t.Fatalf("Unfollow() error = %v", err)
+	}
+
+	if err := follows.Unfollow(ctx, "Brian K. Vaughan"); err == nil {
+		t.Errorf("Unfollow() expected error for creator that is not followed")
+	}
+
+	if got, _ := follows.ListFollows(ctx); len(got) != 0 {
+		t.Errorf("ListFollows() got = %+v, want none", got)
+	}
+}
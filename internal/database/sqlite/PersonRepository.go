@@ -0,0 +1,343 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+type PersonRepository struct {
+	db *sql.DB
+}
+
+func NewPersonRepository(db *sql.DB) *PersonRepository {
+	return &PersonRepository{db}
+}
+
+// ListPeople returns every person with their aliases and the number of books they are credited on, ordered by name.
+// When search is set, only people with an alias containing it are returned.
+func (p *PersonRepository) ListPeople(ctx context.Context, search string) ([]models.Person, error) {
+	stmt := `SELECT p.id, p.name, a.alias,
+            (SELECT COUNT(DISTINCT comic_book_id) FROM creators WHERE person_id = p.id)
+        FROM people AS p
+        JOIN person_aliases AS a ON a.person_id = p.id
+        WHERE ? = '' OR p.id IN (SELECT person_id FROM person_aliases WHERE alias LIKE ? ESCAPE '\')
+        ORDER BY p.name COLLATE NOCASE, p.id, a.alias;`
+
+	key := models.PersonKey(search)
+	pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(key) + "%"
+
+	rows, err := p.db.QueryContext(ctx, stmt, key, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve creators: %v", err)
+	}
+	defer rows.Close()
+
+	var people []models.Person
+
+	for rows.Next() {
+		var person models.Person
+		var alias string
+		if err := rows.Scan(&person.ID, &person.Name, &alias, &person.Books); err != nil {
+			return nil, fmt.Errorf("failed to retrieve creators: %v", err)
+		}
+
+		if n := len(people); n == 0 || people[n-1].ID != person.ID {
+			people = append(people, person)
+		}
+
+		people[len(people)-1].Aliases = append(people[len(people)-1].Aliases, alias)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read creators: %v", err)
+	}
+
+	return people, nil
+}
+
+// AddAlias makes alias resolve to the person known as name. Books already credited to alias as a separate person
+// have to be merged instead.
+func (p *PersonRepository) AddAlias(ctx context.Context, name, alias string) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	person, err := findPerson(ctx, tx, name)
+	if err != nil {
+		return err
+	}
+
+	key := models.PersonKey(alias)
+	if key == "" {
+		return errors.New("alias is empty")
+	}
+
+	existing, err := findPerson(ctx, tx, alias)
+	switch {
+	case err == nil && existing.ID == person.ID:
+		return nil
+	case err == nil:
+		return fmt.Errorf("%s is already known as %s, merge them instead", alias, existing.Name)
+	case !errors.Is(err, models.ErrPersonNotFound):
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "INSERT INTO person_aliases(alias, person_id) VALUES (?, ?)", key, person.ID); err != nil {
+		return fmt.Errorf("failed to store alias: %v", err)
+	}
+
+	return tx.Commit()
+}
+
+// MergePeople folds the person known as from into the person known as into. The aliases, credits and follow of from
+// are moved over and credits are renamed to the name of into. When both are followed, the follow of into is kept.
+func (p *PersonRepository) MergePeople(ctx context.Context, from, into string) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	src, err := findPerson(ctx, tx, from)
+	if err != nil {
+		return err
+	}
+
+	dst, err := findPerson(ctx, tx, into)
+	if err != nil {
+		return err
+	}
+
+	if src.ID == dst.ID {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE person_aliases SET person_id = ? WHERE person_id = ?", dst.ID, src.ID); err != nil {
+		return fmt.Errorf("failed to move aliases: %v", err)
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE OR IGNORE follows SET person_id = ? WHERE person_id = ?", dst.ID, src.ID)
+	if err != nil {
+		return fmt.Errorf("failed to move follow: %v", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM follows WHERE person_id = ?", src.ID); err != nil {
+		return fmt.Errorf("failed to delete follow: %v", err)
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE creators SET person_id = ?, name = ? WHERE person_id = ?", dst.ID, dst.Name, src.ID)
+	if err != nil {
+		return fmt.Errorf("failed to move credits: %v", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM people WHERE id = ?", src.ID); err != nil {
+		return fmt.Errorf("failed to delete creator: %v", err)
+	}
+
+	return tx.Commit()
+}
+
+func findPerson(ctx context.Context, tx *sql.Tx, name string) (models.Person, error) {
+	var person models.Person
+
+	stmt := `SELECT p.id, p.name FROM person_aliases AS a
+        JOIN people AS p ON p.id = a.person_id
+        WHERE a.alias = ?;`
+
+	err := tx.QueryRowContext(ctx, stmt, models.PersonKey(name)).Scan(&person.ID, &person.Name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return person, fmt.Errorf("%w: %s", models.ErrPersonNotFound, name)
+	}
+	if err != nil {
+		return person, fmt.Errorf("failed to retrieve creator: %v", err)
+	}
+
+	return person, nil
+}
+
+// LinkPeople links the credits stored without a person, like those stored before people were tracked, to the person
+// their name resolves to and returns how many were linked. A name that resolves to no one becomes a person named
+// after its spelling that is credited most often, on a tie the one saved first.
+func (p *PersonRepository) LinkPeople(ctx context.Context) (int, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, "SELECT rowid, name FROM creators WHERE person_id IS NULL ORDER BY rowid;")
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve creators: %v", err)
+	}
+
+	type credit struct {
+		rowid int64
+		name  string
+	}
+
+	var credits []credit
+	for rows.Next() {
+		var c credit
+		if err := rows.Scan(&c.rowid, &c.name); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to retrieve creators: %v", err)
+		}
+
+		credits = append(credits, c)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read creators: %v", err)
+	}
+
+	// spellings holds the spellings of every key in the order they were saved, counts how often each is credited.
+	var keys []string
+	spellings := make(map[string][]string)
+	counts := make(map[string]int)
+	for _, c := range credits {
+		key := models.PersonKey(c.name)
+		if key == "" {
+			continue
+		}
+
+		if _, ok := spellings[key]; !ok {
+			keys = append(keys, key)
+		}
+		if counts[c.name] == 0 {
+			spellings[key] = append(spellings[key], c.name)
+		}
+		counts[c.name]++
+	}
+
+	people := make(map[string]models.Person)
+	for _, key := range keys {
+		name := spellings[key][0]
+		for _, s := range spellings[key][1:] {
+			if counts[s] > counts[name] {
+				name = s
+			}
+		}
+
+		if _, err := resolvePerson(ctx, tx, people, name); err != nil {
+			return 0, err
+		}
+	}
+
+	linked := 0
+	for _, c := range credits {
+		person, ok := people[models.PersonKey(c.name)]
+		if !ok {
+			continue
+		}
+
+		_, err := tx.ExecContext(ctx, "UPDATE creators SET person_id = ?, name = ? WHERE rowid = ?", person.ID,
+			person.Name, c.rowid)
+		if err != nil {
+			return 0, fmt.Errorf("failed to link creator: %v", err)
+		}
+		linked++
+	}
+
+	return linked, tx.Commit()
+}
+
+// resolvePerson returns the person a credited name belongs to, creating a person for names that have not been seen
+// before. Resolved names are cached in people, which is keyed on the name key. An empty name resolves to no person.
+func resolvePerson(ctx context.Context, tx *sql.Tx, people map[string]models.Person, name string) (models.Person, error) {
+	key := models.PersonKey(name)
+	if key == "" {
+		return models.Person{}, nil
+	}
+
+	if person, ok := people[key]; ok {
+		return person, nil
+	}
+
+	person, err := findPerson(ctx, tx, name)
+	if errors.Is(err, models.ErrPersonNotFound) {
+		person = models.Person{ID: uuid.New().String(), Name: strings.TrimSpace(name)}
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO people(id, name, created_at) VALUES (?, ?, ?)",
+			person.ID, person.Name, time.Now())
+		if err != nil {
+			return person, fmt.Errorf("failed to store creator: %v", err)
+		}
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO person_aliases(alias, person_id) VALUES (?, ?)", key, person.ID)
+		if err != nil {
+			return person, fmt.Errorf("failed to store alias: %v", err)
+		}
+	}
+	if err != nil {
+		return person, err
+	}
+
+	people[key] = person
+	return person, nil
+}
+
+// SavePerson stores a person with its aliases. When the name or one of the aliases already resolves to a person,
+// the aliases that are not taken yet are added to that person instead.
+func (p *PersonRepository) SavePerson(ctx context.Context, person models.Person) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := savePerson(ctx, tx, person); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func savePerson(ctx context.Context, tx *sql.Tx, person models.Person) error {
+	names := append([]string{person.Name}, person.Aliases...)
+
+	var existing models.Person
+	var err error
+	for _, n := range names {
+		existing, err = findPerson(ctx, tx, n)
+		if err == nil || !errors.Is(err, models.ErrPersonNotFound) {
+			break
+		}
+	}
+
+	switch {
+	case errors.Is(err, models.ErrPersonNotFound):
+		existing = models.Person{ID: uuid.New().String(), Name: strings.TrimSpace(person.Name)}
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO people(id, name, created_at) VALUES (?, ?, ?)",
+			existing.ID, existing.Name, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to store creator: %v", err)
+		}
+	case err != nil:
+		return err
+	}
+
+	for _, n := range names {
+		key := models.PersonKey(n)
+		if key == "" {
+			continue
+		}
+
+		_, err := tx.ExecContext(ctx, "INSERT OR IGNORE INTO person_aliases(alias, person_id) VALUES (?, ?)",
+			key, existing.ID)
+		if err != nil {
+			return fmt.Errorf("failed to store alias: %v", err)
+		}
+	}
+
+	return nil
+}
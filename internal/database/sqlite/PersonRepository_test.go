@@ -0,0 +1,326 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func setupPersonRepository(t *testing.T) (*ComicBookRepository, *PersonRepository) {
+	t.Helper()
+
+	db, teardown := setupDB(t)
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("Error closing db: %s", err.Error())
+		}
+
+		teardown()
+	})
+
+	return NewComicBookRepository(db), NewPersonRepository(db)
+}
+
+func book(title string, creators ...models.Creator) models.ComicBook {
+	return models.ComicBook{
+		Title:       title,
+		Issue:       "1",
+		Publisher:   "image",
+		ReleaseDate: time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC),
+		Creators:    creators,
+	}
+}
+
+func creatorNames(t *testing.T, r *ComicBookRepository) map[string]string {
+	t.Helper()
+
+	cbs, err := r.GetAll(context.Background())
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+
+	names := make(map[string]string)
+	for _, cb := range cbs {
+		for _, cr := range cb.Creators {
+			names[cb.Title] = cr.Name
+		}
+	}
+
+	return names
+}
+
+func TestPersonRepository_BulkSave_ResolvesAliases(t *testing.T) {
+	books, people := setupPersonRepository(t)
+	ctx := context.Background()
+
+	err := books.BulkSave(ctx, []models.ComicBook{
+		book("Saga", models.Creator{Name: "Brian K. Vaughan", Role: models.RoleWriter}),
+		book("Paper Girls", models.Creator{Name: "BRIAN K  VAUGHAN", Role: models.RoleWriter}),
+	})
+	if err != nil {
+		t.Fatalf("BulkSave() error = %v", err)
+	}
+
+	got, err := people.ListPeople(ctx, "")
+	if err != nil {
+		t.Fatalf("ListPeople() error = %v", err)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("ListPeople() got %d people, want 1", len(got))
+	}
+
+	got[0].ID = ""
+	want := models.Person{Name: "Brian K. Vaughan", Aliases: []string{"brian k vaughan"}, Books: 2}
+	if !reflect.DeepEqual(got[0], want) {
+		t.Errorf("ListPeople() got = %+v, want %+v", got[0], want)
+	}
+
+	wantNames := map[string]string{"Saga": "Brian K. Vaughan", "Paper Girls": "Brian K. Vaughan"}
+	if names := creatorNames(t, books); !reflect.DeepEqual(names, wantNames) {
+		t.Errorf("GetAll() creators = %v, want %v", names, wantNames)
+	}
+}
+
+func TestPersonRepository_AddAlias(t *testing.T) {
+	books, people := setupPersonRepository(t)
+	ctx := context.Background()
+
+	err := books.BulkSave(ctx, []models.ComicBook{
+		book("Saga", models.Creator{Name: "Fiona Staples", Role: models.RoleArtist}),
+		book("Monstress", models.Creator{Name: "Sana Takeda", Role: models.RoleArtist}),
+	})
+	if err != nil {
+		t.Fatalf("BulkSave() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		person  string
+		alias   string
+		wantErr error
+	}{
+		{
+			name:   "new alias",
+			person: "fiona staples",
+			alias:  "F. Staples",
+		},
+		{
+			name:   "existing alias of the same person",
+			person: "Fiona Staples",
+			alias:  "FIONA STAPLES",
+		},
+		{
+			name:    "unknown person",
+			person:  "Jonathan Hickman",
+			alias:   "J. Hickman",
+			wantErr: models.ErrPersonNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := people.AddAlias(ctx, tt.person, tt.alias)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("AddAlias() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := people.AddAlias(ctx, "Fiona Staples", "Sana Takeda"); err == nil {
+		t.Errorf("AddAlias() expected error for alias of another person")
+	}
+
+	err = books.BulkSave(ctx, []models.ComicBook{book("Wytches", models.Creator{Name: "F Staples", Role: models.RoleArtist})})
+	if err != nil {
+		t.Fatalf("BulkSave() error = %v", err)
+	}
+
+	if got := creatorNames(t, books)["Wytches"]; got != "Fiona Staples" {
+		t.Errorf("BulkSave() resolved alias to %q, want %q", got, "Fiona Staples")
+	}
+}
+
+func TestPersonRepository_MergePeople(t *testing.T) {
+	books, people := setupPersonRepository(t)
+	ctx := context.Background()
+
+	err := books.BulkSave(ctx, []models.ComicBook{
+		book("Saga", models.Creator{Name: "Brian K. Vaughan", Role: models.RoleWriter}),
+		book("Y The Last Man", models.Creator{Name: "Brian Vaughan", Role: models.RoleWriter}),
+	})
+	if err != nil {
+		t.Fatalf("BulkSave() error = %v", err)
+	}
+
+	if err := people.MergePeople(ctx, "Brian Vaughan", "Brian K. Vaughan"); err != nil {
+		t.Fatalf("MergePeople() error = %v", err)
+	}
+
+	got, err := people.ListPeople(ctx, "vaughan")
+	if err != nil {
+		t.Fatalf("ListPeople() error = %v", err)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("ListPeople() got %d people, want 1", len(got))
+	}
+
+	got[0].ID = ""
+	want := models.Person{Name: "Brian K. Vaughan", Aliases: []string{"brian k vaughan", "brian vaughan"}, Books: 2}
+	if !reflect.DeepEqual(got[0], want) {
+		t.Errorf("ListPeople() got = %+v, want %+v", got[0], want)
+	}
+
+	wantNames := map[string]string{"Saga": "Brian K. Vaughan", "Y The Last Man": "Brian K. Vaughan"}
+	if names := creatorNames(t, books); !reflect.DeepEqual(names, wantNames) {
+		t.Errorf("GetAll() creators = %v, want %v", names, wantNames)
+	}
+
+	if err := people.MergePeople(ctx, "Brian Vaughan", "Unknown"); !errors.Is(err, models.ErrPersonNotFound) {
+		t.Errorf("MergePeople() error = %v, want ErrPersonNotFound", err)
+	}
+}
+
+func TestPersonRepository_SavePerson(t *testing.T) {
+	books, people := setupPersonRepository(t)
+	ctx := context.Background()
+
+	err := books.BulkSave(ctx, []models.ComicBook{
+		book("Saga", models.Creator{Name: "Brian Vaughan", Role: models.RoleWriter}),
+	})
+	if err != nil {
+		t.Fatalf("BulkSave() error = %v", err)
+	}
+
+	saved := []models.Person{
+		{Name: "Brian K. Vaughan", Aliases: []string{"brian vaughan", "bkv"}},
+		{Name: "Fiona Staples", Aliases: []string{"fiona staples"}},
+	}
+	for _, p := range saved {
+		if err := people.SavePerson(ctx, p); err != nil {
+			t.Fatalf("SavePerson() error = %v", err)
+		}
+	}
+
+	got, err := people.ListPeople(ctx, "")
+	if err != nil {
+		t.Fatalf("ListPeople() error = %v", err)
+	}
+
+	for i := range got {
+		got[i].ID, got[i].Books = "", 0
+	}
+
+	want := []models.Person{
+		{Name: "Brian Vaughan", Aliases: []string{"bkv", "brian k vaughan", "brian vaughan"}},
+		{Name: "Fiona Staples", Aliases: []string{"fiona staples"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListPeople() got = %+v, want %+v", got, want)
+	}
+}
+
+func TestPersonRepository_LinkPeople(t *testing.T) {
+	books, people := setupPersonRepository(t)
+	ctx := context.Background()
+
+	if err := people.SavePerson(ctx, models.Person{Name: "Fiona Staples"}); err != nil {
+		t.Fatalf("SavePerson() error = %v", err)
+	}
+
+	for _, q := range []string{
+		"INSERT INTO comic_books(id, title) VALUES ('1', 'Saga')",
+		"INSERT INTO creators(id, comic_book_id, role, name) VALUES ('a', '1', 'writer', 'brian k. vaughan')",
+		"INSERT INTO creators(id, comic_book_id, role, name) VALUES ('b', '1', 'writer', 'Brian K. Vaughan')",
+		"INSERT INTO creators(id, comic_book_id, role, name) VALUES ('b2', '1', 'writer', 'Brian K. Vaughan')",
+		"INSERT INTO creators(id, comic_book_id, role, name) VALUES ('c', '1', 'writer', 'Brian K.	Vaughan')",
+		"INSERT INTO creators(id, comic_book_id, role, name) VALUES ('d', '1', 'writer', 'BRIAN K.    VAUGHAN')",
+		"INSERT INTO creators(id, comic_book_id, role, name) VALUES ('e', '1', 'artist', 'FIONA  STAPLES')",
+	} {
+		if _, err := books.db.ExecContext(ctx, q); err != nil {
+			t.Fatalf("Error running %q: %v", q, err)
+		}
+	}
+
+	n, err := people.LinkPeople(ctx)
+	if err != nil {
+		t.Fatalf("LinkPeople() error = %v", err)
+	}
+
+	if n != 6 {
+		t.Errorf("LinkPeople() linked %d credits, want 6", n)
+	}
+
+	rows, err := books.db.QueryContext(ctx, `SELECT c.name, p.name FROM creators AS c
+        JOIN people AS p ON p.id = c.person_id ORDER BY c.id`)
+	if err != nil {
+		t.Fatalf("Error reading creators: %v", err)
+	}
+	defer rows.Close()
+
+	var got []string
+	for rows.Next() {
+		var credit, person string
+		if err := rows.Scan(&credit, &person); err != nil {
+			t.Fatalf("Error reading creators: %v", err)
+		}
+
+		got = append(got, credit+" = "+person)
+	}
+
+	// Every spelling resolves to one person, named after the spelling credited most often or the known name.
+	want := []string{
+		"Brian K. Vaughan = Brian K. Vaughan",
+		"Brian K. Vaughan = Brian K. Vaughan",
+		"Brian K. Vaughan = Brian K. Vaughan",
+		"Brian K. Vaughan = Brian K. Vaughan",
+		"Brian K. Vaughan = Brian K. Vaughan",
+		"Fiona Staples = Fiona Staples",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LinkPeople() linked %v, want %v", got, want)
+	}
+}
+
+func TestPersonRepository_ListPeople_Search(t *testing.T) {
+	books, people := setupPersonRepository(t)
+	ctx := context.Background()
+
+	err := books.BulkSave(ctx, []models.ComicBook{
+		book("Saga", models.Creator{Name: "Fiona Staples", Role: models.RoleArtist}),
+		book("Paper Girls", models.Creator{Name: "Cliff Chiang", Role: models.RoleArtist}),
+	})
+	if err != nil {
+		t.Fatalf("BulkSave() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		search string
+		want   []string
+	}{
+		{name: "part of a name", search: "staples", want: []string{"Fiona Staples"}},
+		{name: "underscore is no wildcard", search: "f_ona", want: nil},
+		{name: "percent is no wildcard", search: "c%g", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := people.ListPeople(ctx, tt.search)
+			if err != nil {
+				t.Fatalf("ListPeople() error = %v", err)
+			}
+
+			var names []string
+			for _, p := range got {
+				names = append(names, p.Name)
+			}
+
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("ListPeople() got = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
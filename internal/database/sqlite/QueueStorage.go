@@ -0,0 +1,108 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	queueStatusPending    = "pending"
+	queueStatusInProgress = "in_progress"
+	queueStatusDone       = "done"
+)
+
+// QueueStorage is a colly queue.Storage backed by the crawl_queue table. Requests are kept after they are
+// handed out so that a crawl which gets interrupted can pick up the pages that were not completed yet. Every
+// source has its own queue.
+type QueueStorage struct {
+	db     *sql.DB
+	source string
+}
+
+func NewQueueStorage(db *sql.DB, source string) *QueueStorage {
+	return &QueueStorage{db: db, source: source}
+}
+
+// Init puts requests that were in flight when a previous run stopped back in the queue.
+func (q *QueueStorage) Init() error {
+	_, err := q.db.Exec("UPDATE crawl_queue SET status = ?, updated_at = ? WHERE source = ? AND status = ?",
+		queueStatusPending, time.Now(), q.source, queueStatusInProgress)
+	if err != nil {
+		return fmt.Errorf("failed to initialize crawl queue: %v", err)
+	}
+
+	return nil
+}
+
+func (q *QueueStorage) AddRequest(r []byte) error {
+	var req struct {
+		URL string
+	}
+
+	if err := json.Unmarshal(r, &req); err != nil {
+		return fmt.Errorf("failed to read queued request: %v", err)
+	}
+
+	stmt := `
+        INSERT INTO crawl_queue(source, url, request, status, created_at, updated_at)
+        VALUES (?, ?, ?, ?, ?, ?)
+        ON CONFLICT(source, url) DO NOTHING;`
+
+	now := time.Now()
+	if _, err := q.db.Exec(stmt, q.source, req.URL, r, queueStatusPending, now, now); err != nil {
+		return fmt.Errorf("failed to queue request: %v", err)
+	}
+
+	return nil
+}
+
+func (q *QueueStorage) GetRequest() ([]byte, error) {
+	stmt := `
+        UPDATE crawl_queue SET status = ?, updated_at = ?
+        WHERE id = (SELECT id FROM crawl_queue WHERE source = ? AND status = ? ORDER BY id LIMIT 1)
+        RETURNING request;`
+
+	var r []byte
+	err := q.db.QueryRow(stmt, queueStatusInProgress, time.Now(), q.source, queueStatusPending).Scan(&r)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve queued request: %v", err)
+	}
+
+	return r, nil
+}
+
+func (q *QueueStorage) QueueSize() (int, error) {
+	var n int
+	stmt := "SELECT COUNT(*) FROM crawl_queue WHERE source = ? AND status = ?"
+	if err := q.db.QueryRow(stmt, q.source, queueStatusPending).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to count queued requests: %v", err)
+	}
+
+	return n, nil
+}
+
+// MarkDone records that the page at url has been scraped, so it is skipped when resuming.
+func (q *QueueStorage) MarkDone(url string) error {
+	_, err := q.db.Exec("UPDATE crawl_queue SET status = ?, updated_at = ? WHERE source = ? AND url = ?",
+		queueStatusDone, time.Now(), q.source, url)
+	if err != nil {
+		return fmt.Errorf("failed to mark request as done: %v", err)
+	}
+
+	return nil
+}
+
+// Clear removes all requests, finished or not, from the queue.
+func (q *QueueStorage) Clear() error {
+	if _, err := q.db.Exec("DELETE FROM crawl_queue WHERE source = ?", q.source); err != nil {
+		return fmt.Errorf("failed to clear crawl queue: %v", err)
+	}
+
+	return nil
+}
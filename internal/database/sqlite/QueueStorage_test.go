@@ -0,0 +1,139 @@
+package sqlite
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func queuedRequest(url string) []byte {
+	return []byte(fmt.Sprintf(`{"URL":%q,"Method":"GET"}`, url))
+}
+
+func setupQueueStorage(t *testing.T) *QueueStorage {
+	t.Helper()
+
+	db, teardown := setupDB(t)
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("Error closing db: %s", err.Error())
+		}
+
+		teardown()
+	})
+
+	q := NewQueueStorage(db, "test")
+	if err := q.Init(); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	return q
+}
+
+func TestQueueStorage_AddRequest_GetRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		urls     []string
+		wantSize int
+		wantErr  bool
+	}{
+		{
+			name:     "empty queue",
+			urls:     nil,
+			wantSize: 0,
+		},
+		{
+			name:     "adds requests",
+			urls:     []string{"https://example.com/a", "https://example.com/b"},
+			wantSize: 2,
+		},
+		{
+			name:     "ignores duplicate urls",
+			urls:     []string{"https://example.com/a", "https://example.com/a"},
+			wantSize: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := setupQueueStorage(t)
+
+			for _, url := range tt.urls {
+				if err := q.AddRequest(queuedRequest(url)); (err != nil) != tt.wantErr {
+					t.Errorf("AddRequest() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+
+			if got, _ := q.QueueSize(); got != tt.wantSize {
+				t.Errorf("QueueSize() got = %v, want %v", got, tt.wantSize)
+			}
+
+			for i := 0; i < tt.wantSize; i++ {
+				got, err := q.GetRequest()
+				if err != nil {
+					t.Errorf("GetRequest() error = %v", err)
+				}
+				if !reflect.DeepEqual(got, queuedRequest(tt.urls[i])) {
+					t.Errorf("GetRequest() got = %s, want %s", got, queuedRequest(tt.urls[i]))
+				}
+			}
+
+			if got, _ := q.GetRequest(); got != nil {
+				t.Errorf("GetRequest() on empty queue got = %s, want nil", got)
+			}
+		})
+	}
+}
+
+func TestQueueStorage_AddRequest_InvalidRequest(t *testing.T) {
+	q := setupQueueStorage(t)
+
+	if err := q.AddRequest([]byte("not a request")); err == nil {
+		t.Errorf("AddRequest() expected error")
+	}
+}
+
+func TestQueueStorage_Resume(t *testing.T) {
+	q := setupQueueStorage(t)
+
+	for _, url := range []string{"https://example.com/a", "https://example.com/b", "https://example.com/c"} {
+		if err := q.AddRequest(queuedRequest(url)); err != nil {
+			t.Fatalf("AddRequest() error = %v", err)
+		}
+	}
+
+	// a is completed, b is in flight when the run stops and c was never started.
+	for i := 0; i < 2; i++ {
+		if _, err := q.GetRequest(); err != nil {
+			t.Fatalf("GetRequest() error = %v", err)
+		}
+	}
+
+	if err := q.MarkDone("https://example.com/a"); err != nil {
+		t.Fatalf("MarkDone() error = %v", err)
+	}
+
+	if got, _ := q.QueueSize(); got != 1 {
+		t.Errorf("QueueSize() before Init got = %v, want 1", got)
+	}
+
+	if err := q.Init(); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	if got, _ := q.QueueSize(); got != 2 {
+		t.Errorf("QueueSize() after Init got = %v, want 2", got)
+	}
+
+	got, _ := q.GetRequest()
+	if !reflect.DeepEqual(got, queuedRequest("https://example.com/b")) {
+		t.Errorf("GetRequest() got = %s, want %s", got, queuedRequest("https://example.com/b"))
+	}
+
+	if err := q.Clear(); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+
+	if got, _ := q.QueueSize(); got != 0 {
+		t.Errorf("QueueSize() after Clear got = %v, want 0", got)
+	}
+}
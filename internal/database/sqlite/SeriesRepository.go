@@ -0,0 +1,187 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+type SeriesRepository struct {
+	db *sql.DB
+}
+
+func NewSeriesRepository(db *sql.DB) *SeriesRepository {
+	return &SeriesRepository{db}
+}
+
+// LinkSeries links the books stored before series were tracked to their series and returns how many were linked.
+func (s *SeriesRepository) LinkSeries(ctx context.Context) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `SELECT id, title, issue, format, publisher FROM comic_books
+        WHERE series_id IS NULL;`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve comic books: %v", err)
+	}
+
+	books := make(map[string]models.ComicBook)
+	for rows.Next() {
+		var id string
+		var cb models.ComicBook
+		var issue, format, publisher sql.NullString
+		if err := rows.Scan(&id, &cb.Title, &issue, &format, &publisher); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to retrieve comic books: %v", err)
+		}
+
+		cb.Issue, cb.Format, cb.Publisher = issue.String, format.String, publisher.String
+		books[id] = cb
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read comic books: %v", err)
+	}
+
+	series := make(map[string]string)
+	for id, cb := range books {
+		if err := linkSeries(ctx, tx, series, id, cb); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(books), tx.Commit()
+}
+
+const seriesColumns = `s.id, s.title, COALESCE(s.publisher, ''), s.volume, s.start_year, s.kind,
+    (SELECT COUNT(*) FROM comic_books WHERE series_id = s.id)`
+
+// ListSeries returns the series of publisher, or of every publisher when it is empty, ordered by title.
+func (s *SeriesRepository) ListSeries(ctx context.Context, publisher string) ([]models.Series, error) {
+	stmt := `SELECT ` + seriesColumns + `
+        FROM series AS s
+        WHERE ? = '' OR s.publisher = ?
+        ORDER BY s.title COLLATE NOCASE, s.volume, s.start_year, s.kind;`
+
+	return s.querySeries(ctx, stmt, publisher, publisher)
+}
+
+// CollectedSeries returns the series with at least one book in the collection, ordered by title.
+func (s *SeriesRepository) CollectedSeries(ctx context.Context) ([]models.Series, error) {
+	stmt := `SELECT ` + seriesColumns + `
+        FROM series AS s
+        WHERE EXISTS (
+            SELECT 1 FROM collection_items AS ci
+            JOIN comic_books AS cb ON cb.id = ci.comic_book_id
+            WHERE cb.series_id = s.id
+        )
+        ORDER BY s.title COLLATE NOCASE, s.volume, s.start_year, s.kind;`
+
+	return s.querySeries(ctx, stmt)
+}
+
+func (s *SeriesRepository) querySeries(ctx context.Context, stmt string, args ...any) ([]models.Series, error) {
+	rows, err := s.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve series: %v", err)
+	}
+	defer rows.Close()
+
+	var series []models.Series
+	for rows.Next() {
+		var sr models.Series
+		if err := rows.Scan(&sr.ID, &sr.Title, &sr.Publisher, &sr.Volume, &sr.StartYear, &sr.Kind, &sr.Issues); err != nil {
+			return nil, fmt.Errorf("failed to retrieve series: %v", err)
+		}
+
+		series = append(series, sr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read series: %v", err)
+	}
+
+	return series, nil
+}
+
+// SeriesIssues returns the books of a series ordered by issue number, books without a number last.
+func (s *SeriesRepository) SeriesIssues(ctx context.Context, seriesID string) ([]models.SeriesIssue, error) {
+	stmt := `SELECT cb.title, COALESCE(cb.issue, ''), COALESCE(cb.pages, ''), COALESCE(cb.format, ''),
+            COALESCE(cb.price, ''), COALESCE(cb.publisher, ''), cb.release_date, cb.source, COALESCE(cb.url, ''),
+            cb.issue_number, cb.issue_suffix, COALESCE(ci.status, '')
+        FROM comic_books AS cb
+        LEFT JOIN collection_items AS ci ON ci.comic_book_id = cb.id
+        WHERE cb.series_id = ?
+        ORDER BY cb.issue_number IS NULL, cb.issue_number, cb.issue_suffix, cb.release_date;`
+
+	rows, err := s.db.QueryContext(ctx, stmt, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve issues: %v", err)
+	}
+	defer rows.Close()
+
+	var issues []models.SeriesIssue
+	for rows.Next() {
+		var is models.SeriesIssue
+		var number sql.NullFloat64
+		err := rows.Scan(&is.Title, &is.Issue, &is.Pages, &is.Format, &is.Price, &is.Publisher, &is.ReleaseDate,
+			&is.Source, &is.URL, &number, &is.Number.Suffix, &is.Status)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve issues: %v", err)
+		}
+
+		is.Number.Number, is.Number.Numeric = number.Float64, number.Valid
+		issues = append(issues, is)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read issues: %v", err)
+	}
+
+	return issues, nil
+}
+
+// linkSeries stores the series and issue number of the book with the given id, creating the series when it has not
+// been seen before. Series ids are cached in series, which is keyed on the series key.
+func linkSeries(ctx context.Context, tx *sql.Tx, series map[string]string, id string, cb models.ComicBook) error {
+	sr, num := models.SeriesOf(cb)
+	key := sr.Key()
+
+	seriesID, ok := series[key]
+	if !ok {
+		err := tx.QueryRowContext(ctx, "SELECT id FROM series WHERE key = ?", key).Scan(&seriesID)
+		if errors.Is(err, sql.ErrNoRows) {
+			seriesID = uuid.New().String()
+
+			_, err = tx.ExecContext(ctx, `INSERT INTO series(id, key, title, publisher, volume, start_year, kind, created_at)
+                VALUES (?, ?, ?, ?, ?, ?, ?, ?);`,
+				seriesID, key, sr.Title, sr.Publisher, sr.Volume, sr.StartYear, sr.Kind, time.Now())
+		}
+		if err != nil {
+			return fmt.Errorf("failed to store series: %v", err)
+		}
+
+		series[key] = seriesID
+	}
+
+	var number sql.NullFloat64
+	if num.Numeric {
+		number = sql.NullFloat64{Float64: num.Number, Valid: true}
+	}
+
+	_, err := tx.ExecContext(ctx, "UPDATE comic_books SET series_id = ?, issue_number = ?, issue_suffix = ? WHERE id = ?",
+		seriesID, number, num.Suffix, id)
+	if err != nil {
+		return fmt.Errorf("failed to link series: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package sqlite
+
+import (
+	"context"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSeriesRepository_ListSeries_SeriesIssues(t *testing.T) {
+	books, _ := setupPersonRepository(t)
+	series := NewSeriesRepository(books.db)
+	ctx := context.Background()
+
+	release := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	err := books.BulkSave(ctx, []models.ComicBook{
+		{Title: "Batman", Issue: "2", Publisher: "dc", Format: "singles", ReleaseDate: release.AddDate(0, 1, 0)},
+		{Title: "BATMAN", Issue: "1.Mu", Publisher: "dc", Format: "singles", ReleaseDate: release},
+		{Title: "Batman", Issue: "Variant", Publisher: "dc", Format: "singles", ReleaseDate: release},
+		{Title: "Batman Annual", Issue: "1", Publisher: "dc", Format: "singles", ReleaseDate: release},
+		{Title: "Saga", Issue: "80", Publisher: "image", Format: "singles", ReleaseDate: release},
+	})
+	if err != nil {
+		t.Fatalf("BulkSave() error = %v", err)
+	}
+
+	got, err := series.ListSeries(ctx, "dc")
+	if err != nil {
+		t.Fatalf("ListSeries() error = %v", err)
+	}
+
+	for i := range got {
+		got[i].ID = ""
+	}
+
+	want := []models.Series{
+		{Title: "Batman", Publisher: "dc", Kind: models.SeriesAnnual, Issues: 1},
+		{Title: "Batman", Publisher: "dc", Kind: models.SeriesOngoing, Issues: 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ListSeries() got = %+v, want %+v", got, want)
+	}
+
+	all, err := series.ListSeries(ctx, "")
+	if err != nil {
+		t.Fatalf("ListSeries() error = %v", err)
+	}
+
+	if len(all) != 3 {
+		t.Errorf("ListSeries() got %d series, want 3", len(all))
+	}
+
+	ongoing := all[1].ID
+	issues, err := series.SeriesIssues(ctx, ongoing)
+	if err != nil {
+		t.Fatalf("SeriesIssues() error = %v", err)
+	}
+
+	var numbers []models.IssueNumber
+	for _, is := range issues {
+		numbers = append(numbers, is.Number)
+	}
+
+	wantNumbers := []models.IssueNumber{
+		{Number: 1, Numeric: true, Suffix: "Mu"},
+		{Number: 2, Numeric: true},
+		{Suffix: "Variant"},
+	}
+	if !reflect.DeepEqual(numbers, wantNumbers) {
+		t.Errorf("SeriesIssues() numbers = %+v, want %+v", numbers, wantNumbers)
+	}
+}
+
+func TestSeriesRepository_LinkSeries(t *testing.T) {
+	books, _ := setupPersonRepository(t)
+	series := NewSeriesRepository(books.db)
+	ctx := context.Background()
+
+	err := books.BulkSave(ctx, []models.ComicBook{
+		{Title: "Saga", Issue: "80", Publisher: "image"},
+		{Title: "Saga", Issue: "81", Publisher: "image"},
+	})
+	if err != nil {
+		t.Fatalf("BulkSave() error = %v", err)
+	}
+
+	if _, err := books.db.Exec("UPDATE comic_books SET series_id = NULL"); err != nil {
+		t.Fatalf("Error unlinking series: %v", err)
+	}
+
+	n, err := series.LinkSeries(ctx)
+	if err != nil {
+		t.Fatalf("LinkSeries() error = %v", err)
+	}
+
+	if n != 2 {
+		t.Errorf("LinkSeries() linked %d books, want 2", n)
+	}
+
+	got, err := series.ListSeries(ctx, "image")
+	if err != nil {
+		t.Fatalf("ListSeries() error = %v", err)
+	}
+
+	if len(got) != 1 || got[0].Issues != 2 {
+		t.Errorf("ListSeries() got = %+v, want a single series with 2 issues", got)
+	}
+}
+
+func TestSeriesRepository_CollectedSeries(t *testing.T) {
+	books, _ := setupPersonRepository(t)
+	series := NewSeriesRepository(books.db)
+	collection := NewCollectionRepository(books.db)
+	ctx := context.Background()
+
+	saga := models.ComicBook{Title: "Saga", Issue: "80", Publisher: "image"}
+	err := books.BulkSave(ctx, []models.ComicBook{
+		saga,
+		{Title: "Saga", Issue: "81", Publisher: "image"},
+		{Title: "Monstress", Issue: "1", Publisher: "image"},
+	})
+	if err != nil {
+		t.Fatalf("BulkSave() error = %v", err)
+	}
+
+	if err := collection.SaveItem(ctx, models.CollectionItem{ComicBook: saga, Status: models.StatusOwned}); err != nil {
+		t.Fatalf("SaveItem() error = %v", err)
+	}
+
+	got, err := series.CollectedSeries(ctx)
+	if err != nil {
+		t.Fatalf("CollectedSeries() error = %v", err)
+	}
+
+	if len(got) != 1 || got[0].Title != "Saga" {
+		t.Fatalf("CollectedSeries() got = %+v, want only Saga", got)
+	}
+
+	issues, err := series.SeriesIssues(ctx, got[0].ID)
+	if err != nil {
+		t.Fatalf("SeriesIssues() error = %v", err)
+	}
+
+	var statuses []string
+	for _, is := range issues {
+		statuses = append(statuses, is.Status)
+	}
+
+	if want := []string{models.StatusOwned, ""}; !reflect.DeepEqual(statuses, want) {
+		t.Errorf("SeriesIssues() statuses = %v, want %v", statuses, want)
+	}
+}
@@ -0,0 +1,93 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+)
+
+type SyncRunRepository struct {
+	db *sql.DB
+}
+
+func NewSyncRunRepository(db *sql.DB) *SyncRunRepository {
+	return &SyncRunRepository{db}
+}
+
+func (s *SyncRunRepository) SaveRun(ctx context.Context, run models.SyncRun) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, "INSERT INTO sync_runs(source, started_at, finished_at) VALUES (?, ?, ?)",
+		run.Source, run.StartedAt, run.FinishedAt)
+	if err != nil {
+		return fmt.Errorf("failed to store sync run: %v", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to store sync run: %v", err)
+	}
+
+	pageStmt := `
+        INSERT INTO sync_page_quality(sync_run_id, url, books, dropped, missing_pages, missing_price,
+            missing_creators, missing_release_date)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?);`
+
+	for _, p := range run.Pages {
+		_, err := tx.ExecContext(ctx, pageStmt, id, p.URL, p.Books, p.Dropped, p.MissingPages, p.MissingPrice,
+			p.MissingCreators, p.MissingReleaseDate)
+		if err != nil {
+			return fmt.Errorf("failed to store page quality: %v", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// LastRun returns the most recent sync run of source, or of any source when source is empty.
+func (s *SyncRunRepository) LastRun(ctx context.Context, source string) (models.SyncRun, error) {
+	var run models.SyncRun
+
+	stmt := `SELECT id, source, started_at, finished_at FROM sync_runs
+        WHERE ? = '' OR source = ?
+        ORDER BY started_at DESC, id DESC LIMIT 1;`
+
+	err := s.db.QueryRowContext(ctx, stmt, source, source).Scan(&run.ID, &run.Source, &run.StartedAt, &run.FinishedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return run, models.ErrNoSyncRun
+	}
+	if err != nil {
+		return run, fmt.Errorf("failed to retrieve sync run: %v", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT url, books, dropped, missing_pages, missing_price,
+            missing_creators, missing_release_date
+        FROM sync_page_quality WHERE sync_run_id = ? ORDER BY url;`, run.ID)
+	if err != nil {
+		return run, fmt.Errorf("failed to retrieve page quality: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p models.PageQuality
+		err := rows.Scan(&p.URL, &p.Books, &p.Dropped, &p.MissingPages, &p.MissingPrice, &p.MissingCreators,
+			&p.MissingReleaseDate)
+		if err != nil {
+			return run, fmt.Errorf("failed to retrieve page quality: %v", err)
+		}
+
+		run.Pages = append(run.Pages, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return run, fmt.Errorf("failed to read page quality: %v", err)
+	}
+
+	return run, nil
+}
@@ -0,0 +1,80 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSyncRunRepository_SaveRun_LastRun(t *testing.T) {
+	db, teardown := setupDB(t)
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("Error closing db: %s", err.Error())
+		}
+
+		teardown()
+	})
+
+	s := NewSyncRunRepository(db)
+	ctx := context.Background()
+
+	if _, err := s.LastRun(ctx, ""); !errors.Is(err, models.ErrNoSyncRun) {
+		t.Errorf("LastRun() error = %v, want ErrNoSyncRun", err)
+	}
+
+	older := models.SyncRun{
+		Source:     "comicreleases",
+		StartedAt:  time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+		FinishedAt: time.Date(2026, 1, 1, 10, 5, 0, 0, time.UTC),
+		Pages:      []models.PageQuality{{URL: "https://example.com/a", Books: 3, MissingPrice: 1}},
+	}
+	newer := models.SyncRun{
+		Source:     "image-direct",
+		StartedAt:  time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC),
+		FinishedAt: time.Date(2026, 2, 1, 10, 5, 0, 0, time.UTC),
+		Pages: []models.PageQuality{
+			{URL: "https://example.com/b", Books: 2, MissingCreators: 2},
+			{URL: "https://example.com/c", Books: 4, Dropped: 1, MissingReleaseDate: 4},
+		},
+	}
+
+	for _, run := range []models.SyncRun{older, newer} {
+		if err := s.SaveRun(ctx, run); err != nil {
+			t.Fatalf("SaveRun() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		source string
+		want   models.SyncRun
+	}{
+		{
+			name:   "latest of any source",
+			source: "",
+			want:   newer,
+		},
+		{
+			name:   "latest of a source",
+			source: "comicreleases",
+			want:   older,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.LastRun(ctx, tt.source)
+			if err != nil {
+				t.Fatalf("LastRun() error = %v", err)
+			}
+
+			got.ID = 0
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LastRun() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fields are the values a row can be mapped to. Read holds a yes/no value that marks the book as read.
+var Fields = []string{"title", "issue", "publisher", "status", "read", "condition", "price", "date", "location"}
+
+var reLeadingZeros = regexp.MustCompile(`^0+(\d)`)
+
+var dateLayouts = []string{"2006-01-02", "01/02/2006", "1/2/2006", "Jan 2, 2006", "January 2, 2006", "2 Jan 2006"}
+
+// Format describes the columns of a collection export. Columns holds the header names each field may be found
+// under, the first one present in the file is used. Status is used for rows without a status.
+type Format struct {
+	Name    string
+	Columns map[string][]string
+	Status  string
+}
+
+// LeagueOfComicGeeks is the pull list export of League of Comic Geeks. Every row is on the pull list. Its price is
+// the cover price rather than what was paid, so it is not imported.
+var LeagueOfComicGeeks = Format{
+	Name: "locg",
+	Columns: map[string][]string{
+		"title":     {"Series", "Series Name", "Title", "Name"},
+		"issue":     {"Issue", "Issue #", "Issue Number", "Number"},
+		"publisher": {"Publisher"},
+	},
+	Status: models.StatusWanted,
+}
+
+// CLZ is the comic export of CLZ Comics. Every row is in the collection.
+var CLZ = Format{
+	Name: "clz",
+	Columns: map[string][]string{
+		"title":     {"Series", "Series Title", "Title"},
+		"issue":     {"Issue", "Issue Nr", "Issue No", "Issue Number"},
+		"publisher": {"Publisher"},
+		"read":      {"Read It", "Read"},
+		"condition": {"Grade", "Condition"},
+		"price":     {"Purchase Price", "Price Paid"},
+		"date":      {"Purchase Date", "Date Purchased"},
+		"location":  {"Storage Box", "Location", "Storage"},
+	},
+	Status: models.StatusOwned,
+}
+
+// Formats are the built-in formats by name.
+var Formats = map[string]Format{
+	LeagueOfComicGeeks.Name: LeagueOfComicGeeks,
+	CLZ.Name:                CLZ,
+}
+
+// Generic builds a format from mappings like "title=Series Name". A field that is not mapped is looked up under its
+// own name.
+func Generic(mappings []string, status string) (Format, error) {
+	f := Format{Name: "generic", Columns: make(map[string][]string), Status: status}
+	for _, field := range Fields {
+		f.Columns[field] = []string{field}
+	}
+
+	for _, m := range mappings {
+		field, column, ok := strings.Cut(m, "=")
+		field = strings.ToLower(strings.TrimSpace(field))
+		if !ok || strings.TrimSpace(column) == "" {
+			return f, fmt.Errorf("invalid mapping %q, expected field=column", m)
+		}
+
+		if !slices.Contains(Fields, field) {
+			return f, fmt.Errorf("unknown field %q, expected one of: %s", field, strings.Join(Fields, ", "))
+		}
+
+		f.Columns[field] = []string{strings.TrimSpace(column)}
+	}
+
+	return f, nil
+}
+
+// Read parses a CSV export into rows. Rows without a title are skipped. A row with a value that can not be parsed is
+// kept with the reason in Invalid, so the rest of the file is still imported and the row is reported with its line
+// number.
+func Read(r io.Reader, f Format) ([]models.ImportRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %v", err)
+	}
+
+	columns := f.index(header)
+	if _, ok := columns["title"]; !ok {
+		return nil, fmt.Errorf("no title column found, expected one of: %s",
+			strings.Join(f.Columns["title"], ", "))
+	}
+
+	var rows []models.ImportRow
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read line %d: %v", line, err)
+		}
+
+		value := func(field string) string {
+			i, ok := columns[field]
+			if !ok || i >= len(record) {
+				return ""
+			}
+
+			return strings.TrimSpace(record[i])
+		}
+
+		row, err := f.row(line, value)
+		if err != nil {
+			row.Invalid = err.Error()
+		}
+
+		if row.Title != "" {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
+
+// index maps every field to the position of its column in header.
+func (f Format) index(header []string) map[string]int {
+	columns := make(map[string]int)
+
+	for field, names := range f.Columns {
+		for _, name := range names {
+			i := slices.IndexFunc(header, func(h string) bool {
+				return strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")), name)
+			})
+			if i >= 0 {
+				columns[field] = i
+				break
+			}
+		}
+	}
+
+	return columns
+}
+
+func (f Format) row(line int, value func(string) string) (models.ImportRow, error) {
+	row := models.ImportRow{
+		Line:      line,
+		Title:     value("title"),
+		Issue:     reLeadingZeros.ReplaceAllString(strings.TrimPrefix(value("issue"), "#"), "$1"),
+		Publisher: value("publisher"),
+		Item: models.CollectionItem{
+			Status:    f.Status,
+			Condition: value("condition"),
+			Location:  value("location"),
+		},
+	}
+
+	if s := value("status"); s != "" {
+		status, ok := parseStatus(s)
+		if !ok {
+			return row, fmt.Errorf("unknown status %q", s)
+		}
+		row.Item.Status = status
+	}
+
+	if read, _ := strconv.ParseBool(yesNo(value("read"))); read {
+		row.Item.Status = models.StatusRead
+	}
+
+	if p := value("price"); p != "" {
+		price, err := strconv.ParseFloat(strings.NewReplacer("$", "", "€", "", "£", "", ",", "").Replace(p), 64)
+		if err != nil {
+			return row, fmt.Errorf("invalid price %q", p)
+		}
+		row.Item.PurchasePrice = price
+	}
+
+	if d := value("date"); d != "" {
+		date, err := parseDate(d)
+		if err != nil {
+			return row, err
+		}
+		row.Item.PurchaseDate = date
+	}
+
+	return row, nil
+}
+
+func parseStatus(s string) (string, bool) {
+	switch s = strings.ToLower(s); s {
+	case "pull", "pull list", "wish list", "wishlist", "want":
+		return models.StatusWanted, true
+	case "on order", "preordered", "pre-ordered":
+		return models.StatusOrdered, true
+	case "collection", "in collection", "have":
+		return models.StatusOwned, true
+	}
+
+	return s, slices.Contains(models.CollectionStatuses, s)
+}
+
+func yesNo(s string) string {
+	switch strings.ToLower(s) {
+	case "yes", "y", "x":
+		return "true"
+	case "no", "n":
+		return "false"
+	}
+
+	return s
+}
+
+func parseDate(s string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
@@ -0,0 +1,162 @@
+package importer
+
+import (
+	"github.com/MikkelvtK/solipull/internal/models"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRead(t *testing.T) {
+	generic, err := Generic([]string{"title=Comic", "issue=No."}, models.StatusOwned)
+	if err != nil {
+		t.Fatalf("Generic() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		csv     string
+		format  Format
+		want    []models.ImportRow
+		wantErr bool
+	}{
+		{
+			name:   "league of comic geeks",
+			csv:    "Series,Issue #,Publisher,Price\nBatman (2025),#003,DC Comics,$4.99\n,,,\n",
+			format: LeagueOfComicGeeks,
+			want: []models.ImportRow{
+				{
+					Line:      2,
+					Title:     "Batman (2025)",
+					Issue:     "3",
+					Publisher: "DC Comics",
+					Item:      models.CollectionItem{Status: models.StatusWanted},
+				},
+			},
+		},
+		{
+			name: "clz",
+			csv: "\ufeffSeries,Issue Nr,Publisher,Grade,Purchase Price,Purchase Date,Storage Box,Read It\n" +
+				"Saga,80,Image,9.8,3.99,2026-03-18,Box 1,Yes\n" +
+				"Saga,81,Image,,,,,No\n",
+			format: CLZ,
+			want: []models.ImportRow{
+				{
+					Line:      2,
+					Title:     "Saga",
+					Issue:     "80",
+					Publisher: "Image",
+					Item: models.CollectionItem{
+						Status:        models.StatusRead,
+						Condition:     "9.8",
+						PurchasePrice: 3.99,
+						PurchaseDate:  time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC),
+						Location:      "Box 1",
+					},
+				},
+				{
+					Line:      3,
+					Title:     "Saga",
+					Issue:     "81",
+					Publisher: "Image",
+					Item:      models.CollectionItem{Status: models.StatusOwned},
+				},
+			},
+		},
+		{
+			name:   "generic",
+			csv:    "Comic,No.,status\nMonstress,0.5,pull list\n",
+			format: generic,
+			want: []models.ImportRow{
+				{
+					Line:  2,
+					Title: "Monstress",
+					Issue: "0.5",
+					Item:  models.CollectionItem{Status: models.StatusWanted},
+				},
+			},
+		},
+		{
+			name:    "no title column",
+			csv:     "Name,Issue\nSaga,80\n",
+			format:  CLZ,
+			wantErr: true,
+		},
+		{
+			name:   "invalid values",
+			csv:    "Series,Issue,Purchase Price,Purchase Date\nSaga,80,free,\nSaga,81,,soon\nSaga,82,3.99,\n",
+			format: CLZ,
+			want: []models.ImportRow{
+				{
+					Line:    2,
+					Title:   "Saga",
+					Issue:   "80",
+					Item:    models.CollectionItem{Status: models.StatusOwned},
+					Invalid: `invalid price "free"`,
+				},
+				{
+					Line:    3,
+					Title:   "Saga",
+					Issue:   "81",
+					Item:    models.CollectionItem{Status: models.StatusOwned},
+					Invalid: `invalid date "soon"`,
+				},
+				{
+					Line:  4,
+					Title: "Saga",
+					Issue: "82",
+					Item:  models.CollectionItem{Status: models.StatusOwned, PurchasePrice: 3.99},
+				},
+			},
+		},
+		{
+			name:    "empty file",
+			csv:     "",
+			format:  CLZ,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Read(strings.NewReader(tt.csv), tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Read() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Read() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeneric(t *testing.T) {
+	tests := []struct {
+		name     string
+		mappings []string
+		wantErr  bool
+	}{
+		{
+			name:     "valid",
+			mappings: []string{"title=Series Name", "location=Box"},
+		},
+		{
+			name:     "unknown field",
+			mappings: []string{"grade=Grade"},
+			wantErr:  true,
+		},
+		{
+			name:     "missing column",
+			mappings: []string{"title"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Generic(tt.mappings, models.StatusOwned); (err != nil) != tt.wantErr {
+				t.Errorf("Generic() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
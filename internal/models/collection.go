@@ -0,0 +1,49 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	StatusWanted  = "wanted"
+	StatusOrdered = "ordered"
+	StatusOwned   = "owned"
+	StatusRead    = "read"
+)
+
+// CollectionStatuses are the statuses of a collection item, in the order a book usually moves through them. Wanted
+// and ordered books make up the pull list.
+var CollectionStatuses = []string{StatusWanted, StatusOrdered, StatusOwned, StatusRead}
+
+var ErrNotInCollection = errors.New("book is not in the collection")
+
+type CollectionRepository interface {
+	FindBooks(ctx context.Context, title, issue, publisher string) ([]ComicBook, error)
+	SaveItem(ctx context.Context, item CollectionItem) error
+	UpdateStatus(ctx context.Context, cb ComicBook, status string) error
+	RemoveItem(ctx context.Context, cb ComicBook) error
+	ListItems(ctx context.Context, status string) ([]CollectionItem, error)
+}
+
+// CollectionItem is a book on the pull list or in the collection. Books are identified by their title, issue,
+// publisher and release date.
+type CollectionItem struct {
+	ComicBook
+	Status        string    `json:"status"`
+	Condition     string    `json:"condition,omitempty"`
+	PurchasePrice float64   `json:"purchase_price,omitempty"`
+	PurchaseDate  time.Time `json:"purchase_date,omitempty"`
+	Location      string    `json:"location,omitempty"`
+}
+
+// Owned reports whether the book is physically in the collection.
+func (c CollectionItem) Owned() bool {
+	return c.Status == StatusOwned || c.Status == StatusRead
+}
+
+// Pulled reports whether the book is on the pull list: wanted or ordered, but not yet owned.
+func (c CollectionItem) Pulled() bool {
+	return c.Status == StatusWanted || c.Status == StatusOrdered
+}
@@ -2,21 +2,54 @@ package models
 
 import (
 	"context"
+	"iter"
 	"time"
 )
 
 type ComicBookRepository interface {
 	BulkSave(ctx context.Context, records []ComicBook) error
 	GetAll(ctx context.Context) ([]ComicBook, error)
+	Books(ctx context.Context, q BookQuery) iter.Seq2[ComicBook, error]
+	Prune(ctx context.Context, before time.Time, dryRun bool) (PruneSummary, error)
 }
 
 type ComicBook struct {
-	Title       string
-	Issue       string
-	Pages       string
-	Format      string
-	Price       string
-	Creators    []Creator
-	Publisher   string
-	ReleaseDate time.Time
+	Title       string    `json:"title"`
+	Issue       string    `json:"issue"`
+	Pages       string    `json:"pages"`
+	Format      string    `json:"format"`
+	Price       string    `json:"price"`
+	Creators    []Creator `json:"creators"`
+	Publisher   string    `json:"publisher"`
+	ReleaseDate time.Time `json:"release_date"`
+	Source      string    `json:"source"`
+	URL         string    `json:"url"`
+	// Description is the solicitation text.
+	Description string `json:"description"`
+}
+
+// BookSort is the order books are read in. Every order falls back on the other fields, so books come out the same
+// way on every run.
+type BookSort string
+
+const (
+	// SortByRelease orders on release date, publisher, title and issue. It is the default.
+	SortByRelease BookSort = "release"
+	// SortByTitle orders on title, issue, release date and publisher.
+	SortByTitle BookSort = "title"
+	// SortByPublisher orders on publisher, release date, title and issue.
+	SortByPublisher BookSort = "publisher"
+)
+
+var BookSorts = []BookSort{SortByRelease, SortByTitle, SortByPublisher}
+
+// BookQuery selects the books to read. Fields left empty do not filter, and a zero Limit reads every book.
+type BookQuery struct {
+	Publishers []string
+	Months     []time.Month
+	// Search matches the title or a creator name, ignoring case.
+	Search string
+	Sort   BookSort
+	Limit  int
+	Offset int
 }
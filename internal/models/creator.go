@@ -1,6 +1,21 @@
 package models
 
+// Canonical creator roles. Parsers map the role labels found in solicitations onto these.
+const (
+	RoleWriter      = "writer"
+	RoleArtist      = "artist"
+	RolePenciller   = "penciller"
+	RoleInker       = "inker"
+	RoleColorist    = "colorist"
+	RoleLetterer    = "letterer"
+	RoleCoverArtist = "cover artist"
+	RoleEditor      = "editor"
+)
+
+var CreatorRoles = []string{RoleWriter, RoleArtist, RolePenciller, RoleInker, RoleColorist, RoleLetterer,
+	RoleCoverArtist, RoleEditor}
+
 type Creator struct {
-	Role string
-	Name string
+	Role string `json:"role"`
+	Name string `json:"name"`
 }
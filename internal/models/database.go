@@ -0,0 +1,47 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+type DatabaseMaintenance interface {
+	Backup(ctx context.Context, dst string) (string, error)
+	Restore(ctx context.Context, src string) error
+	Migrations(ctx context.Context) ([]Migration, error)
+	Migrate(ctx context.Context) (string, error)
+	MigrateTo(ctx context.Context, version int64) (string, error)
+	Check(ctx context.Context) (IntegrityReport, error)
+	Vacuum(ctx context.Context) (before int64, after int64, err error)
+}
+
+// Migration is a schema migration embedded in the build. AppliedAt is zero for pending migrations.
+type Migration struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// IntegrityReport lists the problems found in the database. A report without problems is healthy.
+type IntegrityReport struct {
+	Integrity   []string
+	ForeignKeys []ForeignKeyViolation
+	// OrphanCreators are credits of books that no longer exist.
+	OrphanCreators int
+	// UnlinkedCreators are credits that do not resolve to a person, so they are missing from creator lists and
+	// follows.
+	UnlinkedCreators int
+}
+
+// ForeignKeyViolation is a row referring to a parent row that does not exist.
+type ForeignKeyViolation struct {
+	Table  string
+	RowID  int64
+	Parent string
+}
+
+func (r IntegrityReport) Healthy() bool {
+	return len(r.Integrity) == 0 && len(r.ForeignKeys) == 0 && r.OrphanCreators == 0 &&
+		r.UnlinkedCreators == 0
+}
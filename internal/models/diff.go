@@ -0,0 +1,20 @@
+package models
+
+// SyncDiff is the difference between the books found by a sync and the books that are stored.
+type SyncDiff struct {
+	New     []ComicBook       `json:"new"`
+	Changed []ComicBookChange `json:"changed"`
+	Removed []ComicBook       `json:"removed"`
+}
+
+// ComicBookChange is a scraped book that is already stored, but with different values for some fields.
+type ComicBookChange struct {
+	ComicBook ComicBook     `json:"comic_book"`
+	Fields    []FieldChange `json:"fields"`
+}
+
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// DumpVersion is the version of the dump format written by this build. Loading accepts this version and older.
+const DumpVersion = 1
+
+// Dump is a copy of everything a user would not want to lose, in a format that does not depend on the database
+// schema. Series are left out as they are derived from the books, sync runs and the crawl queue are only kept for
+// the next sync.
+type Dump struct {
+	Version    int              `json:"version"`
+	CreatedAt  time.Time        `json:"created_at"`
+	ComicBooks []ComicBook      `json:"comic_books"`
+	People     []Person         `json:"people"`
+	Follows    []Follow         `json:"follows"`
+	Collection []CollectionItem `json:"collection"`
+}
+
+type DumpRepository interface {
+	LoadDump(ctx context.Context, dump Dump) error
+}
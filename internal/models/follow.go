@@ -0,0 +1,28 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+type FollowRepository interface {
+	Follow(ctx context.Context, name string, roles []string) error
+	Unfollow(ctx context.Context, name string) error
+	ListFollows(ctx context.Context) ([]Follow, error)
+	FollowedBooks(ctx context.Context, q FollowedBooksQuery) ([]ComicBook, error)
+}
+
+// Follow is a creator whose books are tracked. When Roles is empty, books are tracked whatever the creator is
+// credited for.
+type Follow struct {
+	Name  string   `json:"name"`
+	Roles []string `json:"roles"`
+}
+
+// FollowedBooksQuery selects stored books featuring followed creators. Books are released in [From, To) and were
+// first stored at or after AddedSince. A zero To leaves the range open ended.
+type FollowedBooksQuery struct {
+	From       time.Time
+	To         time.Time
+	AddedSince time.Time
+}
@@ -0,0 +1,13 @@
+package models
+
+// ImportRow is a row of a collection export from another tool, with the entry it should become once matched against
+// a stored book.
+type ImportRow struct {
+	Line      int
+	Title     string
+	Issue     string
+	Publisher string
+	Item      CollectionItem
+	// Invalid is why the row can not be imported, like a price that can not be parsed. It is empty for valid rows.
+	Invalid string
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode"
+)
+
+var ErrPersonNotFound = errors.New("creator not found")
+
+type PersonRepository interface {
+	ListPeople(ctx context.Context, search string) ([]Person, error)
+	AddAlias(ctx context.Context, name, alias string) error
+	MergePeople(ctx context.Context, from, into string) error
+	SavePerson(ctx context.Context, p Person) error
+	LinkPeople(ctx context.Context) (int, error)
+}
+
+// Person is the identity behind the creator credits of different books. Every spelling of the name that has been
+// seen, or added by hand, is an alias that resolves to the person.
+type Person struct {
+	ID      string   `json:"-"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+	Books   int      `json:"-"`
+}
+
+var (
+	nameParticles = map[string]bool{"da": true, "das": true, "de": true, "del": true, "della": true, "der": true,
+		"di": true, "do": true, "dos": true, "du": true, "la": true, "le": true, "van": true, "von": true}
+	romanNumerals = map[string]bool{"ii": true, "iii": true, "iv": true}
+)
+
+// PersonKey returns the key names are matched on, so that "TOM KING", "Tom  King" and "tom king" resolve to the
+// same person.
+func PersonKey(name string) string {
+	name = strings.ReplaceAll(name, ".", "")
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// PersonDisplayName capitalizes a name, keeping particles like "de" and "van" lower case and handling prefixes
+// like "Mc" and "O'". Generational suffixes like "III" are upper case.
+func PersonDisplayName(name string) string {
+	words := strings.Fields(strings.ToLower(name))
+
+	for i, w := range words {
+		switch {
+		case i > 0 && i < len(words)-1 && nameParticles[w]:
+			continue
+		case i > 0 && romanNumerals[w]:
+			words[i] = strings.ToUpper(w)
+		default:
+			words[i] = capitalizeName(w)
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+func capitalizeName(w string) string {
+	r := []rune(w)
+	upper := true
+
+	for i, c := range r {
+		if upper {
+			r[i] = unicode.ToUpper(c)
+		}
+
+		upper = c == '-' || c == '\'' || c == '’' || (i == 1 && r[0] == 'M' && c == 'c')
+	}
+
+	return string(r)
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var reRetention = regexp.MustCompile(`^(\d+)\s*(d|w|mo|y)$`)
+
+// Retention is how long books are kept after their release date, written like "90d", "8w", "24mo" or "2y". The zero
+// value keeps books forever.
+type Retention struct {
+	Years  int
+	Months int
+	Days   int
+}
+
+func ParseRetention(s string) (Retention, error) {
+	m := reRetention.FindStringSubmatch(s)
+	if m == nil {
+		return Retention{}, fmt.Errorf("invalid retention %q, use a number followed by d, w, mo or y like 24mo", s)
+	}
+
+	n, err := strconv.Atoi(m[1])
+	if err != nil || n == 0 {
+		return Retention{}, fmt.Errorf("invalid retention %q, it has to be longer than 0", s)
+	}
+
+	switch m[2] {
+	case "d":
+		return Retention{Days: n}, nil
+	case "w":
+		return Retention{Days: n * 7}, nil
+	case "mo":
+		return Retention{Months: n}, nil
+	default:
+		return Retention{Years: n}, nil
+	}
+}
+
+func (r Retention) IsZero() bool {
+	return r == Retention{}
+}
+
+// Cutoff returns the release date before which books fall outside the retention.
+func (r Retention) Cutoff(now time.Time) time.Time {
+	return now.AddDate(-r.Years, -r.Months, -r.Days)
+}
+
+func (r Retention) String() string {
+	switch {
+	case r.Years > 0:
+		return fmt.Sprintf("%dy", r.Years)
+	case r.Months > 0:
+		return fmt.Sprintf("%dmo", r.Months)
+	case r.Days%7 == 0 && r.Days > 0:
+		return fmt.Sprintf("%dw", r.Days/7)
+	default:
+		return fmt.Sprintf("%dd", r.Days)
+	}
+}
+
+func (r *Retention) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	if s == "" {
+		*r = Retention{}
+		return nil
+	}
+
+	parsed, err := ParseRetention(s)
+	if err != nil {
+		return err
+	}
+
+	*r = parsed
+	return nil
+}
+
+// PruneSummary is what pruning deleted, or would delete on a dry run.
+type PruneSummary struct {
+	Before   time.Time
+	Books    int
+	Creators int
+	Series   int
+	// Kept are the books released before the cutoff that stay, as they are in the collection or on the pull list.
+	Kept int
+}
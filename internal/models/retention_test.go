@@ -0,0 +1,44 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseRetention(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    Retention
+		wantErr bool
+	}{
+		{s: "90d", want: Retention{Days: 90}},
+		{s: "8w", want: Retention{Days: 56}},
+		{s: "24mo", want: Retention{Months: 24}},
+		{s: "2y", want: Retention{Years: 2}},
+		{s: "24m", wantErr: true},
+		{s: "0y", wantErr: true},
+		{s: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseRetention(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRetention() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRetention() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetention_Cutoff(t *testing.T) {
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	want := time.Date(2024, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	if got := (Retention{Months: 24}).Cutoff(now); !got.Equal(want) {
+		t.Errorf("Cutoff() = %v, want %v", got, want)
+	}
+}
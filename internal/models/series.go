@@ -0,0 +1,182 @@
+package models
+
+import (
+	"cmp"
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	SeriesOngoing = "ongoing"
+	SeriesAnnual  = "annual"
+	SeriesOneShot = "one-shot"
+	// SeriesCollected holds the trades and hardcovers of a title, numbered by volume. They are kept apart from the
+	// singles, so volume 10 never takes the place of issue 10.
+	SeriesCollected = "collected"
+)
+
+type SeriesRepository interface {
+	LinkSeries(ctx context.Context) (int, error)
+	ListSeries(ctx context.Context, publisher string) ([]Series, error)
+	CollectedSeries(ctx context.Context) ([]Series, error)
+	SeriesIssues(ctx context.Context, seriesID string) ([]SeriesIssue, error)
+}
+
+// Series is the run a book belongs to. Runs of the same title are told apart by volume and start year, annuals,
+// one-shots and collected editions are series of their own.
+type Series struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Publisher string `json:"publisher"`
+	Volume    int    `json:"volume,omitempty"`
+	StartYear int    `json:"start_year,omitempty"`
+	Kind      string `json:"kind"`
+	Issues    int    `json:"issues"`
+}
+
+// Key identifies the series independent of how its title is capitalized or spaced.
+func (s Series) Key() string {
+	return strings.Join([]string{normalizeTitle(s.Title), strings.ToLower(s.Publisher), strconv.Itoa(s.Volume),
+		strconv.Itoa(s.StartYear), s.Kind}, "|")
+}
+
+// SeriesIssue is a book of a series with its parsed number. Status is its collection status, empty when the book is
+// not in the collection.
+type SeriesIssue struct {
+	ComicBook
+	Number IssueNumber
+	Status string
+}
+
+// IssueNumber is an issue split into its numeric part and whatever follows it, so "1.MU" is issue 1 with suffix
+// "MU" and "½" is issue 0.5. Issues without a number, like "A", only have a suffix.
+type IssueNumber struct {
+	Number  float64
+	Numeric bool
+	Suffix  string
+}
+
+var (
+	reIssueNumber  = regexp.MustCompile(`^(-?\d+(?:\.\d+)?|½|¼|¾)(.*)$`)
+	reStartYear    = regexp.MustCompile(`\s*\((\d{4})\)`)
+	reVolume       = regexp.MustCompile(`(?i)\s+vol(?:ume)?\.?\s*(\d+)$`)
+	reAnnual       = regexp.MustCompile(`(?i)\s+annual$`)
+	reOneShot      = regexp.MustCompile(`(?i)\s+one[\s-]?shot$`)
+	reCollected    = regexp.MustCompile(`(?i)\s+(?:tp|tpb|hc|gn|sc|omnibus)$`)
+	fractionIssues = map[string]float64{"½": 0.5, "¼": 0.25, "¾": 0.75}
+)
+
+// ParseIssue splits an issue into its number and suffix. A leading "Annual" is dropped, the series carries that.
+func ParseIssue(s string) IssueNumber {
+	s = strings.TrimSpace(s)
+	if rest, ok := cutPrefixFold(s, "annual"); ok {
+		s = strings.TrimSpace(rest)
+	}
+
+	m := reIssueNumber.FindStringSubmatch(s)
+	if m == nil {
+		return IssueNumber{Suffix: s}
+	}
+
+	n, ok := fractionIssues[m[1]]
+	if !ok {
+		n, _ = strconv.ParseFloat(m[1], 64)
+	}
+
+	return IssueNumber{
+		Number:  n,
+		Numeric: true,
+		Suffix:  strings.TrimSpace(strings.TrimLeft(m[2], ".")),
+	}
+}
+
+// String joins the number and suffix the way variants are numbered, so issue 1 with suffix "A" is "1A". The only dot
+// is the one of a decimal number.
+func (n IssueNumber) String() string {
+	if !n.Numeric {
+		return n.Suffix
+	}
+
+	num := strconv.FormatFloat(n.Number, 'f', -1, 64)
+	for f, v := range fractionIssues {
+		if v == n.Number {
+			num = f
+		}
+	}
+
+	switch {
+	case n.Suffix == "":
+		return num
+	case strings.Contains(n.Suffix, " "):
+		return num + " " + n.Suffix
+	default:
+		return num + n.Suffix
+	}
+}
+
+// Compare orders issues the way the database does: numbered issues first by number, then by suffix.
+func (n IssueNumber) Compare(o IssueNumber) int {
+	switch {
+	case n.Numeric != o.Numeric && n.Numeric:
+		return -1
+	case n.Numeric != o.Numeric:
+		return 1
+	case n.Number != o.Number:
+		return cmp.Compare(n.Number, o.Number)
+	default:
+		return strings.Compare(n.Suffix, o.Suffix)
+	}
+}
+
+// SeriesOf derives the series of a book and its number within that series from the title and issue. Collected
+// editions like "Monstress Vol. 10 TP" belong to the collected series of the title and use the volume as their
+// number.
+func SeriesOf(cb ComicBook) (Series, IssueNumber) {
+	title := strings.TrimSpace(cb.Title)
+	s := Series{Publisher: strings.ToLower(cb.Publisher), Kind: SeriesOngoing}
+
+	if m := reStartYear.FindStringSubmatch(title); m != nil {
+		s.StartYear, _ = strconv.Atoi(m[1])
+		title = strings.TrimSpace(reStartYear.ReplaceAllString(title, ""))
+	}
+
+	collected := reCollected.MatchString(title) || (cb.Format != "" && cb.Format != "singles")
+	title = reCollected.ReplaceAllString(title, "")
+
+	issue := cb.Issue
+	if m := reVolume.FindStringSubmatch(title); m != nil {
+		title = reVolume.ReplaceAllString(title, "")
+		if collected && issue == "" {
+			issue = m[1]
+		} else {
+			s.Volume, _ = strconv.Atoi(m[1])
+		}
+	}
+
+	if collected {
+		s.Kind = SeriesCollected
+	} else if _, ok := cutPrefixFold(strings.TrimSpace(issue), "annual"); ok || reAnnual.MatchString(title) {
+		s.Kind = SeriesAnnual
+		title = reAnnual.ReplaceAllString(title, "")
+	} else if reOneShot.MatchString(title) {
+		s.Kind = SeriesOneShot
+		title = reOneShot.ReplaceAllString(title, "")
+	}
+
+	s.Title = strings.Join(strings.Fields(title), " ")
+	return s, ParseIssue(issue)
+}
+
+func normalizeTitle(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+
+	return s[len(prefix):], true
+}
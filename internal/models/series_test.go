@@ -0,0 +1,208 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestParseIssue(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want IssueNumber
+	}{
+		{
+			name: "empty",
+			s:    "",
+			want: IssueNumber{},
+		},
+		{
+			name: "number",
+			s:    "12",
+			want: IssueNumber{Number: 12, Numeric: true},
+		},
+		{
+			name: "point one",
+			s:    "1.5",
+			want: IssueNumber{Number: 1.5, Numeric: true},
+		},
+		{
+			name: "marvel unlimited suffix",
+			s:    "1.MU",
+			want: IssueNumber{Number: 1, Numeric: true, Suffix: "MU"},
+		},
+		{
+			name: "fraction",
+			s:    "½",
+			want: IssueNumber{Number: 0.5, Numeric: true},
+		},
+		{
+			name: "annual",
+			s:    "Annual 1",
+			want: IssueNumber{Number: 1, Numeric: true},
+		},
+		{
+			name: "edition",
+			s:    "1 Facsimile Edition",
+			want: IssueNumber{Number: 1, Numeric: true, Suffix: "Facsimile Edition"},
+		},
+		{
+			name: "no number",
+			s:    "Alpha",
+			want: IssueNumber{Suffix: "Alpha"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseIssue(tt.s); got != tt.want {
+				t.Errorf("ParseIssue() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIssueNumber_String(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{s: "1", want: "1"},
+		{s: "1A", want: "1A"},
+		{s: "1.MU", want: "1MU"},
+		{s: "1 Facsimile Edition", want: "1 Facsimile Edition"},
+		{s: "½", want: "½"},
+		{s: "0.5", want: "½"},
+		{s: "1.5", want: "1.5"},
+		{s: "Alpha", want: "Alpha"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			if got := ParseIssue(tt.s).String(); got != tt.want {
+				t.Errorf("String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeriesOf(t *testing.T) {
+	tests := []struct {
+		name       string
+		cb         ComicBook
+		wantSeries Series
+		wantIssue  IssueNumber
+	}{
+		{
+			name:       "ongoing",
+			cb:         ComicBook{Title: "Batman", Issue: "1", Publisher: "DC", Format: "singles"},
+			wantSeries: Series{Title: "Batman", Publisher: "dc", Kind: SeriesOngoing},
+			wantIssue:  IssueNumber{Number: 1, Numeric: true},
+		},
+		{
+			name:       "start year",
+			cb:         ComicBook{Title: "Batman (2025)", Issue: "3", Publisher: "dc"},
+			wantSeries: Series{Title: "Batman", Publisher: "dc", StartYear: 2025, Kind: SeriesOngoing},
+			wantIssue:  IssueNumber{Number: 3, Numeric: true},
+		},
+		{
+			name:       "volume",
+			cb:         ComicBook{Title: "Batman Vol 3", Issue: "150", Publisher: "dc", Format: "singles"},
+			wantSeries: Series{Title: "Batman", Publisher: "dc", Volume: 3, Kind: SeriesOngoing},
+			wantIssue:  IssueNumber{Number: 150, Numeric: true},
+		},
+		{
+			name:       "annual in title",
+			cb:         ComicBook{Title: "Batman Annual", Issue: "1", Publisher: "dc"},
+			wantSeries: Series{Title: "Batman", Publisher: "dc", Kind: SeriesAnnual},
+			wantIssue:  IssueNumber{Number: 1, Numeric: true},
+		},
+		{
+			name:       "annual in issue",
+			cb:         ComicBook{Title: "Batman", Issue: "Annual 2", Publisher: "dc"},
+			wantSeries: Series{Title: "Batman", Publisher: "dc", Kind: SeriesAnnual},
+			wantIssue:  IssueNumber{Number: 2, Numeric: true},
+		},
+		{
+			name:       "one-shot",
+			cb:         ComicBook{Title: "Joker One-Shot", Publisher: "dc"},
+			wantSeries: Series{Title: "Joker", Publisher: "dc", Kind: SeriesOneShot},
+			wantIssue:  IssueNumber{},
+		},
+		{
+			name:       "collected edition",
+			cb:         ComicBook{Title: "Monstress Vol. 10 Tp", Publisher: "image", Format: "trades"},
+			wantSeries: Series{Title: "Monstress", Publisher: "image", Kind: SeriesCollected},
+			wantIssue:  IssueNumber{Number: 10, Numeric: true},
+		},
+		{
+			name:       "hardcover without format",
+			cb:         ComicBook{Title: "Batman Vol. 3 HC", Publisher: "dc"},
+			wantSeries: Series{Title: "Batman", Publisher: "dc", Kind: SeriesCollected},
+			wantIssue:  IssueNumber{Number: 3, Numeric: true},
+		},
+		{
+			name:       "omnibus",
+			cb:         ComicBook{Title: "Saga Omnibus", Publisher: "image", Format: "hardcovers"},
+			wantSeries: Series{Title: "Saga", Publisher: "image", Kind: SeriesCollected},
+			wantIssue:  IssueNumber{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSeries, gotIssue := SeriesOf(tt.cb)
+			if gotSeries != tt.wantSeries {
+				t.Errorf("SeriesOf() series = %+v, want %+v", gotSeries, tt.wantSeries)
+			}
+			if gotIssue != tt.wantIssue {
+				t.Errorf("SeriesOf() issue = %+v, want %+v", gotIssue, tt.wantIssue)
+			}
+		})
+	}
+}
+
+func TestSeriesOf_CollectedApartFromSingles(t *testing.T) {
+	tests := []struct {
+		name      string
+		single    ComicBook
+		collected ComicBook
+	}{
+		{
+			name:      "trade",
+			single:    ComicBook{Title: "Monstress", Issue: "10", Publisher: "image", Format: "singles"},
+			collected: ComicBook{Title: "Monstress Vol. 10 TP", Publisher: "image", Format: "trades"},
+		},
+		{
+			name:      "hardcover",
+			single:    ComicBook{Title: "Batman", Issue: "3", Publisher: "dc", Format: "singles"},
+			collected: ComicBook{Title: "Batman Vol. 3 HC", Publisher: "dc", Format: "hardcovers"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			single, _ := SeriesOf(tt.single)
+			collected, _ := SeriesOf(tt.collected)
+			if single.Key() == collected.Key() {
+				t.Errorf("SeriesOf() gives %q and %q the same series %q", tt.single.Title, tt.collected.Title,
+					single.Key())
+			}
+		})
+	}
+}
+
+func TestIssueNumber_Compare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "2", b: "10", want: -1},
+		{a: "1", b: "1.MU", want: -1},
+		{a: "½", b: "1", want: -1},
+		{a: "A", b: "1", want: 1},
+		{a: "3", b: "3", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			if got := ParseIssue(tt.a).Compare(ParseIssue(tt.b)); got != tt.want {
+				t.Errorf("Compare() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package models
+
+// SyncDefaults are the publishers and months synced when none are given and solipull can not ask for them, like in
+// a cron job. Months are month names or "current" and "next", which are worked out on every sync.
+type SyncDefaults struct {
+	Publishers []string `json:"publishers"`
+	Months     []string `json:"months"`
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrNoSyncRun = errors.New("no sync run found")
+
+type SyncRunRepository interface {
+	SaveRun(ctx context.Context, run SyncRun) error
+	LastRun(ctx context.Context, source string) (SyncRun, error)
+}
+
+// SyncRun records a sync of a source together with the extraction quality of every page that was scraped.
+type SyncRun struct {
+	ID         int64
+	Source     string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Pages      []PageQuality
+}
+
+// The fields whose extraction is tracked per page. A warning about a field names it under FieldKey, so the warnings of
+// a sync can be counted per page.
+const (
+	FieldKey = "field"
+
+	FieldTitle       = "title"
+	FieldPages       = "pages"
+	FieldPrice       = "price"
+	FieldCreators    = "creators"
+	FieldReleaseDate = "release_date"
+)
+
+// PageQuality counts how many of the books on a page lack each of the extracted fields. Items without a title are
+// dropped instead of scraped, so they are counted separately.
+type PageQuality struct {
+	URL                string
+	Books              int
+	Dropped            int
+	MissingPages       int
+	MissingPrice       int
+	MissingCreators    int
+	MissingReleaseDate int
+}
+
+// Missing counts a warning about field.
+func (p *PageQuality) Missing(field string) {
+	switch field {
+	case FieldTitle:
+		p.Dropped++
+	case FieldPages:
+		p.MissingPages++
+	case FieldPrice:
+		p.MissingPrice++
+	case FieldCreators:
+		p.MissingCreators++
+	case FieldReleaseDate:
+		p.MissingReleaseDate++
+	}
+}
+
+// Items returns the number of books found on the page, including the dropped ones.
+func (p PageQuality) Items() int {
+	return p.Books + p.Dropped
+}
+
+// FillRate returns the fraction of items on the page that have a field, given how many lack it.
+func (p PageQuality) FillRate(missing int) float64 {
+	if p.Items() == 0 {
+		return 0
+	}
+
+	return float64(p.Items()-missing) / float64(p.Items())
+}
+
+// Degraded reports whether the page produced no books or the fill rate of any field on it is below threshold.
+func (p PageQuality) Degraded(threshold float64) bool {
+	if p.Books == 0 {
+		return true
+	}
+
+	for _, m := range []int{p.Dropped, p.MissingPages, p.MissingPrice, p.MissingCreators, p.MissingReleaseDate} {
+		if p.FillRate(m) < threshold {
+			return true
+		}
+	}
+
+	return false
+}
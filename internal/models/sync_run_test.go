@@ -0,0 +1,23 @@
+package models
+
+import "testing"
+
+func TestPageQuality_Degraded(t *testing.T) {
+	tests := []struct {
+		name string
+		p    PageQuality
+		want bool
+	}{
+		{name: "complete", p: PageQuality{Books: 10, MissingPrice: 1}, want: false},
+		{name: "no books", p: PageQuality{}, want: true},
+		{name: "dropped items", p: PageQuality{Books: 3, Dropped: 1}, want: true},
+		{name: "missing field", p: PageQuality{Books: 10, MissingCreators: 3}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Degraded(0.8); got != tt.want {
+				t.Errorf("Degraded() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
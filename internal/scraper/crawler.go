@@ -0,0 +1,322 @@
+package scraper
+
+import (
+	"context"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/MikkelvtK/solipull/internal/service"
+	"github.com/gocolly/colly/v2"
+	"github.com/gocolly/colly/v2/queue"
+	"github.com/gocolly/colly/v2/storage"
+	"log/slog"
+	"strconv"
+)
+
+// QueueStore is a queue storage that keeps track of completed requests, which allows an interrupted crawl to
+// be resumed.
+type QueueStore interface {
+	queue.Storage
+	MarkDone(url string) error
+	Clear() error
+}
+
+// previewQueueSize is the most pages a preview queues, far more than a sync of a few months finds.
+const previewQueueSize = 100000
+
+// previewQueue returns an in-memory queue with the threads of q, for scraping without touching the stored queue.
+func previewQueue(q *queue.Queue) (*queue.Queue, error) {
+	return queue.New(q.Threads, &queue.InMemoryQueueStorage{MaxSize: previewQueueSize})
+}
+
+// crawler is the crawl the providers share. It visits the start page, queues the solicitation pages found on it and
+// scrapes those for books, and it resumes an interrupted crawl from the stored queue. A provider only supplies how
+// the solicitation pages are found and how a book is parsed.
+type crawler struct {
+	name     string
+	startURL string
+	navCol   *colly.Collector
+	solCol   *colly.Collector
+	queue    *queue.Queue
+	store    QueueStore
+	logger   *slog.Logger
+
+	// links binds the search for solicitation pages on the start page to nav, which calls found for every page.
+	links func(nav *colly.Collector, found func(url string))
+	// item selects a book on a solicitation page, parse turns it into one or reports false when it holds none.
+	item  string
+	parse func(ctx context.Context, e *colly.HTMLElement) (models.ComicBook, bool)
+
+	observer service.ScrapingObserver
+	ctx      context.Context
+	res      chan<- models.ComicBook
+}
+
+func (c *crawler) Name() string {
+	return c.name
+}
+
+func (c *crawler) StartURL() string {
+	return c.startURL
+}
+
+func (c *crawler) GetData(ctx context.Context, url string, results chan<- models.ComicBook, obs service.ScrapingObserver) error {
+	err := c.start(ctx, results, obs)
+	defer c.stop()
+
+	if err != nil {
+		return err
+	}
+
+	c.observer.OnStart()
+
+	if c.store != nil {
+		if err := c.store.Clear(); err != nil {
+			return err
+		}
+	}
+
+	if err := c.navCol.Visit(url); err != nil {
+		return err
+	}
+	c.navCol.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return c.runQueue(ctx)
+}
+
+// Preview runs GetData on an in-memory queue, so the stored queue of an interrupted sync is neither cleared nor
+// marked.
+func (c *crawler) Preview(ctx context.Context, url string, results chan<- models.ComicBook, obs service.ScrapingObserver) error {
+	q, err := previewQueue(c.queue)
+	if err != nil {
+		return err
+	}
+
+	stored, store := c.queue, c.store
+	c.queue, c.store = q, nil
+	defer func() { c.queue, c.store = stored, store }()
+
+	return c.GetData(ctx, url, results, obs)
+}
+
+func (c *crawler) Resume(ctx context.Context, results chan<- models.ComicBook, obs service.ScrapingObserver) error {
+	err := c.start(ctx, results, obs)
+	defer c.stop()
+
+	if err != nil {
+		return err
+	}
+
+	c.observer.OnStart()
+
+	n, err := c.queue.Size()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return service.ErrNothingToResume
+	}
+
+	c.observer.OnUrlFound(n)
+	c.observer.OnNavigationComplete()
+
+	return c.runQueue(ctx)
+}
+
+// scrapePages scrapes the given solicitation pages without going through the start page or the queue.
+func (c *crawler) scrapePages(ctx context.Context, urls []string, results chan<- models.ComicBook, obs service.ScrapingObserver) error {
+	err := c.start(ctx, results, obs)
+	defer c.stop()
+
+	if err != nil {
+		return err
+	}
+
+	c.observer.OnStart()
+	c.observer.OnUrlFound(len(urls))
+	c.observer.OnNavigationComplete()
+
+	for _, url := range urls {
+		if err := c.solCol.Visit(url); err != nil {
+			c.observer.OnError(ctx, slog.LevelError, "failed to visit page",
+				"url", url,
+				"err", err.Error())
+		}
+	}
+	c.solCol.Wait()
+
+	return ctx.Err()
+}
+
+// start sets the state of a run. The collectors forget the pages an earlier run visited, so a dry run can be followed
+// by the sync itself.
+func (c *crawler) start(ctx context.Context, results chan<- models.ComicBook, obs service.ScrapingObserver) error {
+	c.ctx = ctx
+	c.res = results
+	c.observer = obs
+
+	for _, col := range []*colly.Collector{c.navCol, c.solCol} {
+		if err := col.SetStorage(&storage.InMemoryStorage{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *crawler) stop() {
+	close(c.res)
+
+	c.ctx = nil
+	c.res = nil
+	c.observer = nil
+}
+
+func (c *crawler) runQueue(ctx context.Context) error {
+	if err := c.queue.Run(c.solCol); err != nil {
+		return err
+	}
+	c.solCol.Wait()
+
+	return ctx.Err()
+}
+
+// bindCallbacks binds the callbacks of the crawl to the collectors. Colly adds callbacks instead of replacing them, so
+// the constructor binds them once and they read the state of the current run from the crawler.
+func (c *crawler) bindCallbacks() {
+	checkCtx := func(r *colly.Request) {
+		if c.ctx != nil && c.ctx.Err() != nil {
+			r.Abort()
+		}
+	}
+
+	logErr := func(r *colly.Response, e error) {
+		c.observer.OnError(c.ctx, slog.LevelError, "request failed",
+			"url", r.Request.URL.String(),
+			"status", strconv.Itoa(r.StatusCode),
+			"error", e.Error())
+	}
+
+	c.navCol.OnRequest(checkCtx)
+	c.solCol.OnRequest(checkCtx)
+	c.solCol.OnRequest(rememberURL)
+
+	c.navCol.OnError(logErr)
+	c.solCol.OnError(logErr)
+	c.observePages()
+
+	if c.links != nil {
+		c.links(c.navCol, c.enqueue)
+	}
+
+	c.navCol.OnScraped(func(r *colly.Response) {
+		if r.StatusCode == 200 {
+			c.observer.OnNavigationComplete()
+		}
+	})
+
+	if c.item != "" && c.parse != nil {
+		c.solCol.OnHTML(c.item, func(e *colly.HTMLElement) {
+			cb, ok := c.parse(service.WithPage(c.ctx, e.Request.URL.String()), e)
+			if !ok {
+				return
+			}
+
+			if c.res != nil {
+				c.res <- cb
+			}
+
+			countBook(e.Request)
+			c.observer.OnComicBookScraped(1)
+		})
+	}
+
+	c.solCol.OnScraped(func(r *colly.Response) {
+		if r.StatusCode == 200 {
+			c.markDone(queuedURL(r))
+			c.observer.OnScrapingComplete()
+		}
+	})
+}
+
+// enqueue adds a solicitation page found on the start page to the queue.
+func (c *crawler) enqueue(url string) {
+	if err := c.queue.AddURL(url); err != nil {
+		c.observer.OnError(c.ctx, slog.LevelError, "failed to add url to queue",
+			"url", url,
+			"err", err.Error())
+		return
+	}
+
+	c.observer.OnUrlFound(1)
+}
+
+func (c *crawler) markDone(url string) {
+	if c.store == nil {
+		return
+	}
+
+	if err := c.store.MarkDone(url); err != nil {
+		c.observer.OnError(c.ctx, slog.LevelError, "failed to mark page as done",
+			"url", url,
+			"err", err.Error())
+	}
+}
+
+// pageBooksKey holds the number of books found on a page in the context of its request.
+const pageBooksKey = "books"
+
+// observePages reports the progress of every solicitation page to observers that implement service.PageObserver.
+// The observer is looked up on every callback, as a scraper gets a new one for every run.
+func (c *crawler) observePages() {
+	pages := func() (service.PageObserver, bool) {
+		po, ok := c.observer.(service.PageObserver)
+		return po, ok
+	}
+
+	c.solCol.OnRequest(func(r *colly.Request) {
+		if po, ok := pages(); ok && c.ctx != nil && c.ctx.Err() == nil {
+			po.OnPageStart(r.URL.String())
+		}
+	})
+
+	c.solCol.OnError(func(r *colly.Response, err error) {
+		if po, ok := pages(); ok {
+			po.OnPageFailed(r.Request.URL.String(), err)
+		}
+	})
+
+	c.solCol.OnScraped(func(r *colly.Response) {
+		if po, ok := pages(); ok && r.StatusCode == 200 {
+			n, _ := r.Ctx.GetAny(pageBooksKey).(int)
+			po.OnPageScraped(r.Request.URL.String(), n)
+		}
+	})
+}
+
+// queuedURLKey holds the URL a page was queued with in the context of its request. A redirect changes the URL of the
+// request, but the queue only knows the one it was given.
+const queuedURLKey = "queuedURL"
+
+// rememberURL puts the URL of r in its context before a redirect can change it.
+func rememberURL(r *colly.Request) {
+	r.Ctx.Put(queuedURLKey, r.URL.String())
+}
+
+// queuedURL returns the URL the page of r was queued with.
+func queuedURL(r *colly.Response) string {
+	if u := r.Ctx.Get(queuedURLKey); u != "" {
+		return u
+	}
+
+	return r.Request.URL.String()
+}
+
+// countBook adds a book to the count of the page it was found on.
+func countBook(r *colly.Request) {
+	n, _ := r.Ctx.GetAny(pageBooksKey).(int)
+	r.Ctx.Put(pageBooksKey, n+1)
+}
@@ -0,0 +1,154 @@
+package scraper
+
+import (
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"log/slog"
+	"regexp"
+	"slices"
+)
+
+var defaultDateLayouts = []string{"1/2/06", "1/2/2006"}
+
+// Field locates a value inside the container of a book. The selector is matched against the descendants of the
+// container's children and Index picks which of the matches to use.
+type Field struct {
+	Selector string
+	Index    int
+}
+
+// Layout describes the markup of a solicitation page. Format is matched against the siblings preceding the
+// container, the id of the closest match is used as the format.
+type Layout struct {
+	Container   string
+	Title       Field
+	Details     Field
+	ReleaseDate Field
+	Description Field
+	Format      string
+}
+
+// ExtractorDefinition declares how books are extracted from a solicitation page, so a change in the markup of a
+// site can be handled with a config change. The patterns are regular expressions, Publisher must contain a group
+// named Pub and Pages a group named Pages.
+type ExtractorDefinition struct {
+	Layout      Layout
+	Publisher   string
+	Pages       string
+	Price       string
+	ReleaseDate string
+	DateLayouts []string
+	Roles       []string
+}
+
+// DefaultDefinition returns the definition matching the Comic Releases solicitation pages.
+func DefaultDefinition() ExtractorDefinition {
+	return ExtractorDefinition{
+		Layout: Layout{
+			Container:   "div.wp-block-columns",
+			Title:       Field{Selector: "p", Index: 0},
+			Details:     Field{Selector: "p", Index: 1},
+			ReleaseDate: Field{Selector: "p", Index: 2},
+			Description: Field{Selector: "p", Index: 3},
+			Format:      "#singles, #trades, #hardcovers",
+		},
+		Publisher:   `(?i)/(?P<Pub>\w+)-[a-zA-Z]+-\d{4}-solicitations`,
+		Pages:       `(?P<Pages>\d+)\s*(?i)(?:pages?|pgs?.?)`,
+		Price:       `\$(\d+\.\d{2})`,
+		ReleaseDate: `(?i)(\d{1,2}/\d{1,2}/\d{2,4})`,
+		DateLayouts: slices.Clone(defaultDateLayouts),
+		Roles:       slices.Clone(models.CreatorRoles),
+	}
+}
+
+// Merge returns a copy of d where every field that is set in o is replaced by the value from o.
+func (d ExtractorDefinition) Merge(o ExtractorDefinition) ExtractorDefinition {
+	if o.Layout.Container != "" {
+		d.Layout.Container = o.Layout.Container
+	}
+	if o.Layout.Title.Selector != "" {
+		d.Layout.Title = o.Layout.Title
+	}
+	if o.Layout.Details.Selector != "" {
+		d.Layout.Details = o.Layout.Details
+	}
+	if o.Layout.ReleaseDate.Selector != "" {
+		d.Layout.ReleaseDate = o.Layout.ReleaseDate
+	}
+	if o.Layout.Description.Selector != "" {
+		d.Layout.Description = o.Layout.Description
+	}
+	if o.Layout.Format != "" {
+		d.Layout.Format = o.Layout.Format
+	}
+	if o.Publisher != "" {
+		d.Publisher = o.Publisher
+	}
+	if o.Pages != "" {
+		d.Pages = o.Pages
+	}
+	if o.Price != "" {
+		d.Price = o.Price
+	}
+	if o.ReleaseDate != "" {
+		d.ReleaseDate = o.ReleaseDate
+	}
+	if len(o.DateLayouts) > 0 {
+		d.DateLayouts = o.DateLayouts
+	}
+	if len(o.Roles) > 0 {
+		d.Roles = o.Roles
+	}
+
+	return d
+}
+
+// NewExtractor compiles a definition into a ComicBookExtractor.
+func NewExtractor(def ExtractorDefinition, l *slog.Logger) (ComicBookExtractor, error) {
+	if def.Layout.Container == "" {
+		return nil, fmt.Errorf("container selector is required")
+	}
+
+	rePublisher, err := compileNamed("publisher", def.Publisher, "Pub")
+	if err != nil {
+		return nil, err
+	}
+
+	rePages, err := compileNamed("pages", def.Pages, "Pages")
+	if err != nil {
+		return nil, err
+	}
+
+	rePrice, err := regexp.Compile(def.Price)
+	if err != nil {
+		return nil, fmt.Errorf("invalid price pattern: %v", err)
+	}
+
+	reReleaseDate, err := regexp.Compile(def.ReleaseDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid release date pattern: %v", err)
+	}
+
+	return &comicReleasesExtractor{
+		rePublisher:   rePublisher,
+		rePages:       rePages,
+		rePrice:       rePrice,
+		reReleaseDate: reReleaseDate,
+		dateLayouts:   def.DateLayouts,
+		creatorParser: newCreatorParser(def.Roles),
+		logger:        l,
+	}, nil
+}
+
+func compileNamed(name, pattern, group string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s pattern: %v", name, err)
+	}
+
+	if re.SubexpIndex(group) < 0 {
+		return nil, fmt.Errorf("%s pattern has no group named %s", name, group)
+	}
+
+	return re, nil
+}
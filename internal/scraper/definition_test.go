@@ -0,0 +1,89 @@
+package scraper
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewExtractor(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     ExtractorDefinition
+		wantErr bool
+	}{
+		{
+			name:    "default definition",
+			def:     DefaultDefinition(),
+			wantErr: false,
+		},
+		{
+			name:    "missing container",
+			def:     ExtractorDefinition{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid pattern",
+			def:     DefaultDefinition().Merge(ExtractorDefinition{Price: `\$(`}),
+			wantErr: true,
+		},
+		{
+			name:    "pattern without named group",
+			def:     DefaultDefinition().Merge(ExtractorDefinition{Pages: `(\d+) pages`}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewExtractor(tt.def, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewExtractor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("NewExtractor() returned nil extractor")
+			}
+		})
+	}
+}
+
+func TestExtractorDefinition_Merge(t *testing.T) {
+	def := DefaultDefinition()
+
+	got := def.Merge(ExtractorDefinition{
+		Layout:      Layout{Container: "article.solicit", Title: Field{Selector: "h2"}},
+		DateLayouts: []string{"January 2, 2006"},
+	})
+
+	want := def
+	want.Layout.Container = "article.solicit"
+	want.Layout.Title = Field{Selector: "h2"}
+	want.DateLayouts = []string{"January 2, 2006"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge() = %+v, want %+v", got, want)
+	}
+
+	if !reflect.DeepEqual(def, DefaultDefinition()) {
+		t.Errorf("Merge() modified the receiver")
+	}
+}
+
+func TestNewExtractor_DateLayouts(t *testing.T) {
+	def := DefaultDefinition().Merge(ExtractorDefinition{
+		ReleaseDate: `(?i)([A-Z][a-z]+ \d{1,2}, \d{4})`,
+		DateLayouts: []string{"January 2, 2006"},
+	})
+
+	ex, err := NewExtractor(def, nil)
+	if err != nil {
+		t.Fatalf("NewExtractor() error = %v", err)
+	}
+
+	got := ex.ReleaseDate(context.Background(), "In stores March 4, 2026", observer{})
+	want := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("ReleaseDate() = %v, want %v", got, want)
+	}
+}
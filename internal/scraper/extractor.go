@@ -8,10 +8,17 @@ import (
 	"golang.org/x/text/language"
 	"log/slog"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 )
 
+var (
+	reCreditBy      = regexp.MustCompile(`(?i)^(.+?)\s+by\s+(.+)$`)
+	reNameSeparator = regexp.MustCompile(`(?i)\s*(?:,|&|\band\b)\s*`)
+	reIssueMarker   = regexp.MustCompile(`#\s*[\d½¼¾]`)
+)
+
 type ComicBookExtractor interface {
 	MatchURL(context.Context, string, models.ErrorObserver) bool
 	SetUrlMatcher([]string, []string)
@@ -20,7 +27,7 @@ type ComicBookExtractor interface {
 	Pages(context.Context, string, models.ErrorObserver) string
 	Price(context.Context, string, models.ErrorObserver) string
 	Publisher(context.Context, string, models.ErrorObserver) string
-	Creators(HTMLNode) []models.Creator
+	Creators(context.Context, HTMLNode, models.ErrorObserver) []models.Creator
 	ReleaseDate(context.Context, string, models.ErrorObserver) time.Time
 }
 
@@ -30,19 +37,14 @@ type comicReleasesExtractor struct {
 	rePages       *regexp.Regexp
 	rePrice       *regexp.Regexp
 	reReleaseDate *regexp.Regexp
+	dateLayouts   []string
 	creatorParser *creatorParser
 	logger        *slog.Logger
 }
 
-func NewComicReleasesExtractor(l *slog.Logger) ComicBookExtractor {
-	return &comicReleasesExtractor{
-		rePublisher:   regexp.MustCompile(`(?i)/(?P<Pub>\w+)-[a-zA-Z]+-\d{4}-solicitations`),
-		rePages:       regexp.MustCompile(`(?P<Pages>\d+)\s*(?i)(?:pages?|pgs?.?)`),
-		rePrice:       regexp.MustCompile(`\$(\d+\.\d{2})`),
-		reReleaseDate: regexp.MustCompile(`(?i)(\d{1,2}/\d{1,2}/\d{2,4})`),
-		creatorParser: newCreatorParser([]string{"writer", "artist", "cover artist"}),
-		logger:        l,
-	}
+// NewComicReleasesExtractor returns the extractor of the Comic Releases definition.
+func NewComicReleasesExtractor(l *slog.Logger) (ComicBookExtractor, error) {
+	return NewExtractor(DefaultDefinition(), l)
 }
 
 func (c *comicReleasesExtractor) SetUrlMatcher(months, publishers []string) {
@@ -63,41 +65,54 @@ func (c *comicReleasesExtractor) MatchURL(ctx context.Context, url string, obser
 }
 
 func (c *comicReleasesExtractor) Title(ctx context.Context, s string, observer models.ErrorObserver) string {
-	split := strings.Split(s, "#")
-	if len(split[0]) == 0 {
-		observer.OnError(ctx, slog.LevelWarn, "title not found", "string", s)
+	title, _ := splitTitle(s)
+	if strings.TrimSpace(title) == "" {
+		observer.OnError(ctx, slog.LevelWarn, "title not found", "string", s, models.FieldKey, models.FieldTitle)
 		return ""
 	}
 
-	title := cases.Title(language.English).String(split[0])
+	title = cases.Title(language.English).String(title)
 	return strings.TrimSpace(title)
 }
 
 func (c *comicReleasesExtractor) Issue(s string) string {
-	split := strings.Split(s, "#")
-	if len(split) < 2 {
-		return ""
-	}
+	_, issue := splitTitle(s)
 
-	issue := cases.Title(language.English).String(split[1])
+	issue = cases.Title(language.English).String(issue)
 	return strings.TrimSpace(issue)
 }
 
+// splitTitle splits a solicitation title on the "#" that starts the issue number, so a "#" that is part of the
+// title, like in "#DRCL Midnight Children #5", or of the issue, like in "Batman #1 (of #5)", is kept.
+func splitTitle(s string) (string, string) {
+	for _, loc := range reIssueMarker.FindAllStringIndex(s, -1) {
+		if loc[0] > 0 {
+			return s[:loc[0]], s[loc[0]+1:]
+		}
+	}
+
+	if i := strings.LastIndex(s, "#"); i > 0 {
+		return s[:i], s[i+1:]
+	}
+
+	return s, ""
+}
+
 func (c *comicReleasesExtractor) Pages(ctx context.Context, s string, observer models.ErrorObserver) string {
 	if c.rePages == nil {
-		observer.OnError(ctx, slog.LevelWarn, "pages regex is nil")
+		observer.OnError(ctx, slog.LevelWarn, "pages regex is nil", models.FieldKey, models.FieldPages)
 		return ""
 	}
 
 	matches := c.rePages.FindStringSubmatch(s)
 	if matches == nil {
-		observer.OnError(ctx, slog.LevelWarn, "no matches for pages found", "string", s)
+		observer.OnError(ctx, slog.LevelWarn, "no matches for pages found", "string", s, models.FieldKey, models.FieldPages)
 		return ""
 	}
 
 	i := c.rePages.SubexpIndex("Pages")
 	if i < 0 {
-		observer.OnError(ctx, slog.LevelWarn, "no index for pages found", "string", s)
+		observer.OnError(ctx, slog.LevelWarn, "no index for pages found", "string", s, models.FieldKey, models.FieldPages)
 		return ""
 	}
 
@@ -106,10 +121,15 @@ func (c *comicReleasesExtractor) Pages(ctx context.Context, s string, observer m
 
 func (c *comicReleasesExtractor) Price(ctx context.Context, s string, observer models.ErrorObserver) string {
 	if c.rePrice == nil {
-		observer.OnError(ctx, slog.LevelWarn, "price regex is nil")
+		observer.OnError(ctx, slog.LevelWarn, "price regex is nil", models.FieldKey, models.FieldPrice)
 		return ""
 	}
-	return c.rePrice.FindString(s)
+
+	price := c.rePrice.FindString(s)
+	if price == "" {
+		observer.OnError(ctx, slog.LevelWarn, "no price found", "string", s, models.FieldKey, models.FieldPrice)
+	}
+	return price
 }
 
 func (c *comicReleasesExtractor) Publisher(ctx context.Context, s string, observer models.ErrorObserver) string {
@@ -132,29 +152,41 @@ func (c *comicReleasesExtractor) Publisher(ctx context.Context, s string, observ
 	return strings.ToLower(matches[i])
 }
 
-func (c *comicReleasesExtractor) Creators(n HTMLNode) []models.Creator {
-	return c.creatorParser.parse(n)
+func (c *comicReleasesExtractor) Creators(ctx context.Context, n HTMLNode, observer models.ErrorObserver) []models.Creator {
+	creators := c.creatorParser.parse(n)
+	if len(creators) == 0 {
+		observer.OnError(ctx, slog.LevelWarn, "no creators found", models.FieldKey, models.FieldCreators)
+	}
+	return creators
 }
 
 func (c *comicReleasesExtractor) ReleaseDate(ctx context.Context, s string, observer models.ErrorObserver) time.Time {
 	if c.reReleaseDate == nil {
-		observer.OnError(ctx, slog.LevelWarn, "release date regex is nil")
+		observer.OnError(ctx, slog.LevelWarn, "release date regex is nil", models.FieldKey, models.FieldReleaseDate)
 		return time.Time{}
 	}
 
 	d := c.reReleaseDate.FindString(s)
 	if d == "" {
+		observer.OnError(ctx, slog.LevelWarn, "no release date found", "string", s,
+			models.FieldKey, models.FieldReleaseDate)
 		return time.Time{}
 	}
 
-	for _, layout := range []string{"1/2/06", "1/2/2006"} {
+	layouts := c.dateLayouts
+	if len(layouts) == 0 {
+		layouts = defaultDateLayouts
+	}
+
+	for _, layout := range layouts {
 		t, err := time.Parse(layout, d)
 		if err == nil {
 			return t
 		}
 	}
 
-	observer.OnError(ctx, slog.LevelWarn, "failed to parse release date", "string", s)
+	observer.OnError(ctx, slog.LevelWarn, "failed to parse release date", "string", s,
+		models.FieldKey, models.FieldReleaseDate)
 	return time.Time{}
 }
 
@@ -184,22 +216,20 @@ func (c *creatorParser) parse(n HTMLNode) []models.Creator {
 			return
 		}
 
-		for _, role := range c.roles {
-			v := strings.ToLower(strings.TrimSpace(s.Text()))
+		roles, names := c.splitLine(strings.TrimSpace(s.Text()))
+		if len(roles) == 0 {
+			return
+		}
 
-			if !strings.HasPrefix(v, role) {
+		for _, name := range reNameSeparator.Split(names, -1) {
+			name = strings.TrimSpace(name)
+			if name == "" {
 				continue
 			}
 
-			split := strings.Split(v, ":")
-			names := strings.Split(split[1], ",")
-			for _, name := range names {
-				namesNoAnd := strings.ReplaceAll(name, "and", "")
-				NamesNoAmpersand := strings.ReplaceAll(namesNoAnd, "&", "")
-				namesNoSpace := strings.TrimSpace(NamesNoAmpersand)
-				nameFinal := cases.Title(language.English).String(namesNoSpace)
+			nameFinal := models.PersonDisplayName(name)
+			for _, role := range roles {
 				results = append(results, models.Creator{Name: nameFinal, Role: role})
-
 			}
 		}
 	})
@@ -207,6 +237,35 @@ func (c *creatorParser) parse(n HTMLNode) []models.Creator {
 	return results
 }
 
+// splitLine separates a credit line like "Writer/Artist: NAME" or "Art by NAME" into the canonical roles the
+// parser is configured for and the names they are assigned to.
+func (c *creatorParser) splitLine(v string) ([]string, string) {
+	label, names, ok := strings.Cut(v, ":")
+	if !ok || canonicalRoles(label) == nil {
+		m := reCreditBy.FindStringSubmatch(v)
+		if m == nil {
+			return nil, ""
+		}
+
+		label, names = m[1], m[2]
+	}
+
+	roles := make([]string, 0)
+	for _, r := range canonicalRoles(label) {
+		if c.accepts(r) && !slices.Contains(roles, r) {
+			roles = append(roles, r)
+		}
+	}
+
+	return roles, names
+}
+
+func (c *creatorParser) accepts(role string) bool {
+	return slices.ContainsFunc(c.roles, func(r string) bool {
+		return r == role || canonicalRole(r) == role
+	})
+}
+
 func generateUrlRegex(months []string, publishers []string) string {
 	if len(publishers) == 0 || len(months) == 0 {
 		return ""
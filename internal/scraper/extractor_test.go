@@ -31,29 +31,31 @@ func (m MockNode) NodeName() string {
 	return m.name
 }
 
+// newTestExtractor returns the extractor of the default definition.
+func newTestExtractor(t *testing.T) ComicBookExtractor {
+	t.Helper()
+
+	ex, err := NewComicReleasesExtractor(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ex
+}
+
 func TestNewComicReleasesExtractor(t *testing.T) {
-	tests := []struct {
-		name string
-		want ComicBookExtractor
-	}{
-		{
-			name: "nil == no errors",
-			want: &comicReleasesExtractor{
-				rePublisher:   regexp.MustCompile(`(?i)/(?P<Pub>\w+)-[a-zA-Z]+-\d{4}-solicitations`),
-				rePages:       regexp.MustCompile(`(?P<Pages>\d+)\s*(?i)(?:pages?|pgs?.?)`),
-				rePrice:       regexp.MustCompile(`\$(\d+\.\d{2})`),
-				reReleaseDate: regexp.MustCompile(`(?i)(\d{1,2}/\d{1,2}/\d{2,4})`),
-				creatorParser: newCreatorParser([]string{"writer", "artist", "cover artist"}),
-				logger:        slog.Default(),
-			},
-		},
+	want, err := NewExtractor(DefaultDefinition(), slog.Default())
+	if err != nil {
+		t.Fatal(err)
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := NewComicReleasesExtractor(slog.Default()); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("NewComicReleasesExtractor() = %v, want %v", got, tt.want)
-			}
-		})
+
+	got, err := NewComicReleasesExtractor(slog.Default())
+	if err != nil {
+		t.Fatalf("NewComicReleasesExtractor() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewComicReleasesExtractor() = %v, want %v", got, want)
 	}
 }
 
@@ -110,6 +112,100 @@ func Test_comicReleasesExtractor_Creators(t *testing.T) {
 			},
 			want: []models.Creator{},
 		},
+		{
+			name: "normalizes plural roles",
+			fields: fields{
+				creatorParser: newCreatorParser(models.CreatorRoles),
+			},
+			args: args{
+				n: MockNode{text: "Writer(s): MATT FRACTION", name: "#text"},
+			},
+			want: []models.Creator{{Name: "Matt Fraction", Role: "writer"}},
+		},
+		{
+			name: "parses colorists",
+			fields: fields{
+				creatorParser: newCreatorParser(models.CreatorRoles),
+			},
+			args: args{
+				n: MockNode{text: "Colors: TAMRA BONVILLAIN", name: "#text"},
+			},
+			want: []models.Creator{{Name: "Tamra Bonvillain", Role: "colorist"}},
+		},
+		{
+			name: "parses letterers",
+			fields: fields{
+				creatorParser: newCreatorParser(models.CreatorRoles),
+			},
+			args: args{
+				n: MockNode{text: "Letters by CLAYTON COWLES", name: "#text"},
+			},
+			want: []models.Creator{{Name: "Clayton Cowles", Role: "letterer"}},
+		},
+		{
+			name: "assigns combined roles",
+			fields: fields{
+				creatorParser: newCreatorParser(models.CreatorRoles),
+			},
+			args: args{
+				n: MockNode{text: "Writer/Artist: DANIEL WARREN JOHNSON", name: "#text"},
+			},
+			want: []models.Creator{
+				{Name: "Daniel Warren Johnson", Role: "writer"},
+				{Name: "Daniel Warren Johnson", Role: "artist"},
+			},
+		},
+		{
+			name: "assigns pencils and inks",
+			fields: fields{
+				creatorParser: newCreatorParser(models.CreatorRoles),
+			},
+			args: args{
+				n: MockNode{text: "Pencils/Inks: SANDRA HOPE", name: "#text"},
+			},
+			want: []models.Creator{
+				{Name: "Sandra Hope", Role: "penciller"},
+				{Name: "Sandra Hope", Role: "inker"},
+			},
+		},
+		{
+			name: "parses credits without a colon",
+			fields: fields{
+				creatorParser: newCreatorParser(models.CreatorRoles),
+			},
+			args: args{
+				n: MockNode{text: "Art by ALEXANDER LOZANO and FERNANDO BLANCO", name: "#text"},
+			},
+			want: []models.Creator{
+				{Name: "Alexander Lozano", Role: "artist"},
+				{Name: "Fernando Blanco", Role: "artist"},
+			},
+		},
+		{
+			name: "only keeps configured roles",
+			fields: fields{
+				creatorParser: newCreatorParser([]string{"writer"}),
+			},
+			args: args{
+				n: MockNode{text: "Writer/Artist: DANIEL WARREN JOHNSON", name: "#text"},
+			},
+			want: []models.Creator{{Name: "Daniel Warren Johnson", Role: "writer"}},
+		},
+		{
+			name: "capitalizes prefixes and particles",
+			fields: fields{
+				creatorParser: newCreatorParser([]string{"writer", "artist", "cover artist"}),
+			},
+			args: args{
+				n: MockNode{text: "Cover: TODD MCFARLANE, DENNIS O'NEIL, JH WILLIAMS III, MARCO DE LA PENA", name: "#text"},
+			},
+			want: []models.Creator{
+				{Name: "Todd McFarlane", Role: "cover artist"},
+				{Name: "Dennis O'Neil", Role: "cover artist"},
+				{Name: "Jh Williams III", Role: "cover artist"},
+				{Name: "Marco de la Pena", Role: "cover artist"},
+			},
+		},
 		{
 			name: "skips br nodes",
 			fields: fields{
@@ -126,7 +222,7 @@ func Test_comicReleasesExtractor_Creators(t *testing.T) {
 			c := &comicReleasesExtractor{
 				creatorParser: tt.fields.creatorParser,
 			}
-			if got := c.Creators(tt.args.n); !reflect.DeepEqual(got, tt.want) {
+			if got := c.Creators(context.Background(), tt.args.n, observer{}); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Creators() = %v, want %v", got, tt.want)
 			}
 		})
@@ -191,6 +287,20 @@ func Test_comicReleasesExtractor_Issue(t *testing.T) {
 			},
 			want: "1 Facsimile Edition",
 		},
+		{
+			name: "handles a second # in the issue",
+			args: args{
+				s: "BATMAN #1 (OF #5)",
+			},
+			want: "1 (Of #5)",
+		},
+		{
+			name: "handles a # in the title",
+			args: args{
+				s: "#DRCL MIDNIGHT CHILDREN #5",
+			},
+			want: "5",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -533,6 +643,20 @@ func Test_comicReleasesExtractor_Title(t *testing.T) {
 			},
 			want: "Batman",
 		},
+		{
+			name: "handles a second # in the issue",
+			args: args{
+				s: "BATMAN #1 (OF #5)",
+			},
+			want: "Batman",
+		},
+		{
+			name: "handles a # in the title",
+			args: args{
+				s: "#DRCL MIDNIGHT CHILDREN #5",
+			},
+			want: "#Drcl Midnight Children",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
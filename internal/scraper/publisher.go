@@ -0,0 +1,176 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/MikkelvtK/solipull/internal/service"
+	"github.com/gocolly/colly/v2"
+	"github.com/gocolly/colly/v2/queue"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// PublisherSelectors are the CSS selectors that locate a solicitation on a publisher's own release pages. Item
+// selects the element holding a single book, the other selectors are evaluated relative to that element.
+type PublisherSelectors struct {
+	Item        string
+	Title       string
+	Details     string
+	ReleaseDate string
+	Description string
+	Format      string
+}
+
+type PConfig struct {
+	Name      string
+	Publisher string
+	StartURL  string
+	Links     string
+	Selectors PublisherSelectors
+	Nav       *colly.Collector
+	Sol       *colly.Collector
+	Q         *queue.Queue
+	Store     QueueStore
+	Ex        ComicBookExtractor
+	Logger    *slog.Logger
+}
+
+// publisherScraper scrapes the monthly solicitation pages a publisher hosts itself. The start URL is an index
+// page linking to the monthly pages, which are selected by matching the link against the requested months.
+type publisherScraper struct {
+	crawler
+	publisher string
+	sel       PublisherSelectors
+	ex        ComicBookExtractor
+
+	// months match the requested months as words in a link, so "may" does not match "mayhem".
+	months []*regexp.Regexp
+}
+
+func NewPublisherScraper(cfg *PConfig) (service.DataProvider, error) {
+	if cfg == nil {
+		return nil, errors.New("config is nil")
+	}
+
+	if cfg.Selectors.Item == "" || cfg.Selectors.Title == "" {
+		return nil, errors.New("item and title selectors are required")
+	}
+
+	if cfg.Nav == nil || cfg.Sol == nil {
+		return nil, errors.New("navigation and solicitation collectors are required")
+	}
+
+	links := cfg.Links
+	if links == "" {
+		links = "a[href]"
+	}
+
+	s := &publisherScraper{
+		crawler: crawler{
+			name:     cfg.Name,
+			startURL: cfg.StartURL,
+			navCol:   cfg.Nav,
+			solCol:   cfg.Sol,
+			queue:    cfg.Q,
+			store:    cfg.Store,
+			logger:   cfg.Logger,
+			item:     cfg.Selectors.Item,
+		},
+		publisher: strings.ToLower(cfg.Publisher),
+		sel:       cfg.Selectors,
+		ex:        cfg.Ex,
+	}
+
+	s.links = func(nav *colly.Collector, found func(url string)) {
+		nav.OnHTML(links, func(e *colly.HTMLElement) {
+			url := e.Request.AbsoluteURL(e.Attr("href"))
+			if url != "" && s.matchPage(url, e.Text) {
+				found(url)
+			}
+		})
+	}
+	s.parse = s.parseComicBook
+	s.bindCallbacks()
+
+	return s, nil
+}
+
+func (s *publisherScraper) Publishers() []string {
+	return []string{s.publisher}
+}
+
+func (s *publisherScraper) SetInputs(months, publishers []string) error {
+	if !slices.Contains(publishers, s.publisher) {
+		return fmt.Errorf("source %s only provides %s solicitations", s.name, s.publisher)
+	}
+
+	s.months = make([]*regexp.Regexp, 0, len(months))
+	for _, m := range months {
+		word := regexp.QuoteMeta(strings.ToLower(m))
+		s.months = append(s.months, regexp.MustCompile(`(^|[^\pL])`+word+`($|[^\pL])`))
+	}
+
+	return nil
+}
+
+func (s *publisherScraper) GetPages(ctx context.Context, urls []string, publisher string, results chan<- models.ComicBook, obs service.ScrapingObserver) error {
+	if publisher != "" && !strings.EqualFold(publisher, s.publisher) {
+		close(results)
+		return fmt.Errorf("source %s only provides %s solicitations", s.name, s.publisher)
+	}
+
+	return s.scrapePages(ctx, urls, results, obs)
+}
+
+// matchPage reports whether a link on the index page points to the solicitations of one of the requested months.
+func (s *publisherScraper) matchPage(url, text string) bool {
+	v := strings.ToLower(url + " " + text)
+
+	return slices.ContainsFunc(s.months, func(re *regexp.Regexp) bool {
+		return re.MatchString(v)
+	})
+}
+
+func (s *publisherScraper) parseComicBook(ctx context.Context, e *colly.HTMLElement) (models.ComicBook, bool) {
+	raw := strings.TrimSpace(e.DOM.Find(s.sel.Title).First().Text())
+	title := s.ex.Title(ctx, raw, s.observer)
+	if title == "" {
+		return models.ComicBook{}, false
+	}
+
+	cb := models.ComicBook{
+		URL:       e.Request.URL.String(),
+		Publisher: s.publisher,
+		Title:     title,
+		Issue:     s.ex.Issue(raw),
+	}
+
+	details := Wrap(nil)
+	if s.sel.Details != "" {
+		details = Wrap(e.DOM.Find(s.sel.Details).First())
+	}
+
+	cb.Pages = s.ex.Pages(ctx, details.Text(), s.observer)
+	cb.Price = s.ex.Price(ctx, details.Text(), s.observer)
+	cb.Creators = s.ex.Creators(ctx, details, s.observer)
+
+	var date string
+	if s.sel.ReleaseDate != "" {
+		date = e.DOM.Find(s.sel.ReleaseDate).First().Text()
+	}
+	cb.ReleaseDate = s.ex.ReleaseDate(ctx, date, s.observer)
+
+	if s.sel.Description != "" {
+		cb.Description = collapseSpace(e.DOM.Find(s.sel.Description).First().Text())
+	}
+
+	if s.sel.Format != "" {
+		cb.Format = strings.ToLower(strings.TrimSpace(e.DOM.Find(s.sel.Format).First().Text()))
+	}
+
+	return cb, true
+}
@@ -0,0 +1,206 @@
+package scraper
+
+import (
+	"context"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/gocolly/colly/v2"
+	"github.com/gocolly/colly/v2/queue"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testSelectors = PublisherSelectors{
+	Item:        "div.solicit",
+	Title:       ".solicit-title",
+	Details:     ".solicit-credits",
+	ReleaseDate: ".solicit-date",
+	Format:      ".solicit-format",
+}
+
+func setupPublisherServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/publisher_index.html")
+	})
+	mux.HandleFunc("/solicitations/march-2026", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/publisher_solicitations.html")
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func setupPublisherScraper(t *testing.T) *publisherScraper {
+	t.Helper()
+
+	q, _ := queue.New(1, &queue.InMemoryQueueStorage{MaxSize: 10_000})
+
+	p, err := NewPublisherScraper(&PConfig{
+		Name:      "image-direct",
+		Publisher: "Image",
+		Selectors: testSelectors,
+		Nav:       colly.NewCollector(),
+		Sol:       colly.NewCollector(),
+		Q:         q,
+		Ex:        newTestExtractor(t),
+	})
+	if err != nil {
+		t.Fatalf("NewPublisherScraper() error = %v", err)
+	}
+
+	return p.(*publisherScraper)
+}
+
+func TestNewPublisherScraper(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *PConfig
+		wantErr bool
+	}{
+		{
+			name:    "nil config",
+			cfg:     nil,
+			wantErr: true,
+		},
+		{
+			name:    "missing selectors",
+			cfg:     &PConfig{Name: "image-direct"},
+			wantErr: true,
+		},
+		{
+			name:    "missing collectors",
+			cfg:     &PConfig{Name: "image-direct", Selectors: testSelectors},
+			wantErr: true,
+		},
+		{
+			name: "valid config",
+			cfg: &PConfig{Name: "image-direct", Selectors: testSelectors, Nav: colly.NewCollector(),
+				Sol: colly.NewCollector()},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPublisherScraper(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewPublisherScraper() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Name() != tt.cfg.Name {
+				t.Errorf("Name() = %v, want %v", got.Name(), tt.cfg.Name)
+			}
+		})
+	}
+}
+
+func Test_publisherScraper_SetInputs(t *testing.T) {
+	s := setupPublisherScraper(t)
+
+	if err := s.SetInputs([]string{"march"}, []string{"image"}); err != nil {
+		t.Errorf("SetInputs() error = %v", err)
+	}
+
+	if err := s.SetInputs([]string{"march"}, []string{"dc"}); err == nil {
+		t.Errorf("SetInputs() expected error for other publisher")
+	}
+}
+
+func Test_publisherScraper_matchPage(t *testing.T) {
+	s := setupPublisherScraper(t)
+	if err := s.SetInputs([]string{"May"}, []string{"image"}); err != nil {
+		t.Fatalf("SetInputs() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		url  string
+		text string
+		want bool
+	}{
+		{name: "month in url", url: "/solicitations/may-2026", want: true},
+		{name: "month in text", url: "/solicitations/5", text: "Image Comics May 2026 solicitations", want: true},
+		{name: "month inside a word", url: "/series/mayhem", text: "Mayhem #1", want: false},
+		{name: "other month", url: "/solicitations/june-2026", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.matchPage(tt.url, tt.text); got != tt.want {
+				t.Errorf("matchPage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_publisherScraper_GetData(t *testing.T) {
+	ts := setupPublisherServer(t)
+	defer ts.Close()
+
+	s := setupPublisherScraper(t)
+	if err := s.SetInputs([]string{"march"}, []string{"image"}); err != nil {
+		t.Fatalf("SetInputs() error = %v", err)
+	}
+
+	obs := &mockObserver{}
+	obs.On("OnStart").Once()
+	obs.On("OnUrlFound", 1).Once()
+	obs.On("OnNavigationComplete").Once()
+	obs.On("OnComicBookScraped", 1).Twice()
+	obs.On("OnScrapingComplete").Once()
+	obs.On("OnError", mock.Anything, slog.LevelWarn, "title not found",
+		[]any{"string", "", models.FieldKey, models.FieldTitle}).Once()
+
+	results := make(chan models.ComicBook, 10)
+	if err := s.GetData(context.Background(), ts.URL, results, obs); err != nil {
+		t.Errorf("GetData() error = %v", err)
+	}
+
+	var got []models.ComicBook
+	for cb := range results {
+		got = append(got, cb)
+	}
+
+	want := []models.ComicBook{
+		{
+			Title:  "Saga",
+			Issue:  "80",
+			Pages:  "32",
+			Format: "singles",
+			Price:  "$3.99",
+			Creators: []models.Creator{
+				{Name: "Brian K. Vaughan", Role: "writer"},
+				{Name: "Fiona Staples", Role: "artist"},
+				{Name: "Fiona Staples", Role: "cover artist"},
+			},
+			Publisher:   "image",
+			ReleaseDate: time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC),
+			URL:         ts.URL + "/solicitations/march-2026",
+		},
+		{
+			Title:  "Monstress Vol. 10 Tp",
+			Pages:  "168",
+			Format: "trades",
+			Price:  "$19.99",
+			Creators: []models.Creator{
+				{Name: "Marjorie Liu", Role: "writer"},
+				{Name: "Sana Takeda", Role: "artist"},
+			},
+			Publisher:   "image",
+			ReleaseDate: time.Date(2026, 3, 25, 0, 0, 0, 0, time.UTC),
+			URL:         ts.URL + "/solicitations/march-2026",
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetData() got = %+v, want %+v", got, want)
+	}
+
+	obs.AssertExpectations(t)
+}
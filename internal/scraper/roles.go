@@ -0,0 +1,78 @@
+package scraper
+
+import (
+	"github.com/MikkelvtK/solipull/internal/models"
+	"regexp"
+	"strings"
+)
+
+var (
+	reRoleSeparator = regexp.MustCompile(`\s*(?:/|&|,|\band\b)\s*`)
+	reRolePlural    = regexp.MustCompile(`\(s\)|\(es\)`)
+)
+
+// roleAliases maps the role labels used in solicitations onto canonical roles.
+var roleAliases = map[string]string{
+	"writer":        models.RoleWriter,
+	"writers":       models.RoleWriter,
+	"written":       models.RoleWriter,
+	"story":         models.RoleWriter,
+	"script":        models.RoleWriter,
+	"words":         models.RoleWriter,
+	"artist":        models.RoleArtist,
+	"artists":       models.RoleArtist,
+	"art":           models.RoleArtist,
+	"interior art":  models.RoleArtist,
+	"drawn":         models.RoleArtist,
+	"penciller":     models.RolePenciller,
+	"penciler":      models.RolePenciller,
+	"pencils":       models.RolePenciller,
+	"pencil":        models.RolePenciller,
+	"inker":         models.RoleInker,
+	"inks":          models.RoleInker,
+	"inked":         models.RoleInker,
+	"colorist":      models.RoleColorist,
+	"colourist":     models.RoleColorist,
+	"colors":        models.RoleColorist,
+	"colours":       models.RoleColorist,
+	"color artist":  models.RoleColorist,
+	"colored":       models.RoleColorist,
+	"letterer":      models.RoleLetterer,
+	"letters":       models.RoleLetterer,
+	"lettered":      models.RoleLetterer,
+	"cover artist":  models.RoleCoverArtist,
+	"cover artists": models.RoleCoverArtist,
+	"cover":         models.RoleCoverArtist,
+	"main cover":    models.RoleCoverArtist,
+	"editor":        models.RoleEditor,
+	"edited":        models.RoleEditor,
+}
+
+// canonicalRole returns the canonical role for a label, or an empty string when the label is unknown.
+func canonicalRole(label string) string {
+	l := strings.ToLower(label)
+	l = reRolePlural.ReplaceAllString(l, "")
+	l = strings.Join(strings.Fields(l), " ")
+
+	return roleAliases[l]
+}
+
+// canonicalRoles splits a label that may assign several roles at once, like "Writer/Artist" or "Pencils & Inks",
+// into canonical roles. It returns nil when any part of the label is unknown.
+func canonicalRoles(label string) []string {
+	if r := canonicalRole(label); r != "" {
+		return []string{r}
+	}
+
+	var roles []string
+	for _, part := range reRoleSeparator.Split(strings.ToLower(strings.TrimSpace(label)), -1) {
+		r := canonicalRole(part)
+		if r == "" {
+			return nil
+		}
+
+		roles = append(roles, r)
+	}
+
+	return roles
+}
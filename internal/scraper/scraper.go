@@ -11,11 +11,20 @@ import (
 	"github.com/gocolly/colly/v2/queue"
 	"log/slog"
 	"regexp"
-	"strconv"
+	"slices"
 	"strings"
 	"time"
 )
 
+const (
+	ComicReleasesSource   = "comicreleases"
+	ComicReleasesDomain   = "comicreleases.com"
+	ComicReleasesStartURL = "https://" + ComicReleasesDomain + "/sitemap.xml"
+)
+
+// comicReleasesPublishers are the publishers Comic Releases posts the solicitations of.
+var comicReleasesPublishers = []string{"dc", "marvel", "image"}
+
 var (
 	reBrackets = regexp.MustCompile(`[(\[].*?[)\]]`)
 	reAlphaNum = regexp.MustCompile(`[^a-z0-9\s]`)
@@ -27,12 +36,11 @@ func NewCollector(domain string, parallelism int) (*colly.Collector, error) {
 		colly.MaxDepth(1),
 	)
 
-	domainSplit := strings.Split(domain, ".")
-	if len(domainSplit) != 2 {
+	if domain == "" || strings.ContainsAny(domain, "/:%?# ") {
 		return nil, fmt.Errorf("invalid domain: %s", domain)
 	}
 
-	regStr := fmt.Sprintf("^(https?://)?([\\w\\d-]+\\.)?%s\\.%s(/.*)?$", domainSplit[0], domainSplit[1])
+	regStr := fmt.Sprintf(`^(https?://)?([\w-]+\.)*%s(/.*)?$`, regexp.QuoteMeta(domain))
 	c.URLFilters = []*regexp.Regexp{
 		regexp.MustCompile(regStr),
 	}
@@ -48,23 +56,23 @@ func NewCollector(domain string, parallelism int) (*colly.Collector, error) {
 }
 
 type comicReleasesScraper struct {
-	navCol *colly.Collector
-	solCol *colly.Collector
-	queue  *queue.Queue
+	crawler
 	ex     ComicBookExtractor
-	logger *slog.Logger
+	layout Layout
 
-	observer service.ScrapingObserver
-	ctx      context.Context
-	res      chan<- models.ComicBook
+	publisher string
 }
 
 type SConfig struct {
-	Nav    *colly.Collector
-	Sol    *colly.Collector
-	Q      *queue.Queue
-	Ex     ComicBookExtractor
-	Logger *slog.Logger
+	Name     string
+	StartURL string
+	Nav      *colly.Collector
+	Sol      *colly.Collector
+	Q        *queue.Queue
+	Store    QueueStore
+	Ex       ComicBookExtractor
+	Layout   Layout
+	Logger   *slog.Logger
 }
 
 func NewComicReleasesScraper(cfg *SConfig) (service.DataProvider, error) {
@@ -72,46 +80,45 @@ func NewComicReleasesScraper(cfg *SConfig) (service.DataProvider, error) {
 		return nil, errors.New("config is nil")
 	}
 
-	return &comicReleasesScraper{
-		navCol: cfg.Nav,
-		solCol: cfg.Sol,
-		queue:  cfg.Q,
-		ex:     cfg.Ex,
-		logger: cfg.Logger,
-	}, nil
-}
-
-func (s *comicReleasesScraper) GetData(ctx context.Context, url string, results chan<- models.ComicBook, obs service.ScrapingObserver) error {
-	s.ctx = ctx
-	s.res = results
-	s.observer = obs
-
-	s.bindCallbacks(ctx)
-
-	defer func() {
-		s.ctx = nil
-		s.res = nil
-		s.observer = nil
-	}()
-
-	s.observer.OnStart()
-
-	if err := s.navCol.Visit(url); err != nil {
-		return err
+	if cfg.Nav == nil || cfg.Sol == nil {
+		return nil, errors.New("navigation and solicitation collectors are required")
 	}
-	s.navCol.Wait()
 
-	if err := ctx.Err(); err != nil {
-		return err
+	s := &comicReleasesScraper{
+		crawler: crawler{
+			name:     cfg.Name,
+			startURL: cfg.StartURL,
+			navCol:   cfg.Nav,
+			solCol:   cfg.Sol,
+			queue:    cfg.Q,
+			store:    cfg.Store,
+			logger:   cfg.Logger,
+		},
+		ex:     cfg.Ex,
+		layout: cfg.Layout,
 	}
 
-	if err := s.queue.Run(s.solCol); err != nil {
-		return err
+	s.links = s.findPages
+	s.item = s.pageLayout().Container
+	s.parse = func(ctx context.Context, e *colly.HTMLElement) (models.ComicBook, bool) {
+		cb := s.parseComicBook(ctx, e)
+		return cb, cb.Title != ""
 	}
-	s.solCol.Wait()
+	s.bindCallbacks()
 
-	close(results)
-	return nil
+	return s, nil
+}
+
+func (s *comicReleasesScraper) Publishers() []string {
+	return slices.Clone(comicReleasesPublishers)
+}
+
+// GetPages scrapes the given solicitation pages without going through the sitemap or the queue.
+func (s *comicReleasesScraper) GetPages(ctx context.Context, urls []string, publisher string, results chan<- models.ComicBook, obs service.ScrapingObserver) error {
+	s.publisher = publisher
+	defer func() { s.publisher = "" }()
+
+	return s.scrapePages(ctx, urls, results, obs)
 }
 
 func (s *comicReleasesScraper) SetInputs(months, publishers []string) error {
@@ -123,109 +130,111 @@ func (s *comicReleasesScraper) SetInputs(months, publishers []string) error {
 	return nil
 }
 
-func (s *comicReleasesScraper) bindCallbacks(ctx context.Context) {
-	checkCtx := func(r *colly.Request) {
-		if s.ctx != nil && s.ctx.Err() != nil {
-			r.Abort()
-		}
-	}
-
-	logErr := func(r *colly.Response, e error) {
-		s.observer.OnError(s.ctx, slog.LevelError, "request failed",
-			"url", r.Request.URL.String(),
-			"status", strconv.Itoa(r.StatusCode),
-			"error", e.Error())
-	}
-
-	s.navCol.OnRequest(checkCtx)
-	s.solCol.OnRequest(checkCtx)
-
-	s.navCol.OnError(logErr)
-	s.solCol.OnError(logErr)
-
-	s.navCol.OnXML("//loc", func(e *colly.XMLElement) {
-		if !s.ex.MatchURL(ctx, e.Text, s.observer) {
-			return
-		}
-
-		if err := s.queue.AddURL(e.Text); err != nil {
-			s.observer.OnError(s.ctx, slog.LevelError, "failed to add url to queue",
-				"url", e.Text,
-				"err", err.Error())
-			return
-		}
-
-		s.observer.OnUrlFound(1)
-	})
-
-	s.navCol.OnScraped(func(r *colly.Response) {
-		if r.StatusCode == 200 {
-			s.observer.OnNavigationComplete()
-		}
-	})
-
-	s.solCol.OnHTML("div.wp-block-columns", func(e *colly.HTMLElement) {
-		cb := s.parseComicBook(ctx, e)
-		if s.res != nil {
-			s.res <- cb
-		}
-
-		s.observer.OnComicBookScraped(1)
-	})
-
-	s.solCol.OnScraped(func(r *colly.Response) {
-		if r.StatusCode == 200 {
-			s.observer.OnScrapingComplete()
+// findPages finds the solicitation pages of the requested months and publishers in the sitemap.
+func (s *comicReleasesScraper) findPages(nav *colly.Collector, found func(url string)) {
+	nav.OnXML("//loc", func(e *colly.XMLElement) {
+		if s.ex.MatchURL(s.ctx, e.Text, s.observer) {
+			found(e.Text)
 		}
 	})
 }
 
 func (s *comicReleasesScraper) parseComicBook(ctx context.Context, e *colly.HTMLElement) models.ComicBook {
 	var fullTitle string
-	cb := models.ComicBook{}
-	cb.Publisher = s.ex.Publisher(ctx, e.Request.URL.String(), s.observer)
-	cb.Format, _ = e.DOM.PrevAll().Filter("#singles, #trades, #hardcovers").First().Attr("id")
-
-	e.DOM.Children().Find("p").Each(func(i int, sel *goquery.Selection) {
-		switch i {
-		case 0:
-			fullTitle = sel.Text()
-			cb.Title = s.ex.Title(ctx, sel.Text(), s.observer)
-			cb.Issue = s.ex.Issue(sel.Text())
-		case 1:
-			cb.Pages = s.ex.Pages(ctx, sel.Text(), s.observer)
-			cb.Price = s.ex.Price(ctx, sel.Text(), s.observer)
-			cb.Creators = s.ex.Creators(Wrap(sel))
-		case 2:
-			cb.ReleaseDate = s.ex.ReleaseDate(ctx, sel.Text(), s.observer)
-		}
-	})
+	cb := models.ComicBook{URL: e.Request.URL.String()}
+	cb.Publisher = s.publisher
+	if cb.Publisher == "" {
+		cb.Publisher = s.ex.Publisher(ctx, e.Request.URL.String(), s.observer)
+	}
+	layout := s.pageLayout()
 
-	if !cb.ReleaseDate.IsZero() {
-		return cb
+	if layout.Format != "" {
+		cb.Format, _ = e.DOM.PrevAll().Filter(layout.Format).First().Attr("id")
+	}
+
+	if t := s.field(e, layout.Title); t.Length() > 0 {
+		fullTitle = t.Text()
+		cb.Issue = s.ex.Issue(fullTitle)
+	}
+	cb.Title = s.ex.Title(ctx, fullTitle, s.observer)
+
+	d := s.field(e, layout.Details)
+	cb.Pages = s.ex.Pages(ctx, d.Text(), s.observer)
+	cb.Price = s.ex.Price(ctx, d.Text(), s.observer)
+	cb.Creators = s.ex.Creators(ctx, Wrap(d), s.observer)
+
+	var dates []string
+	if r := s.field(e, layout.ReleaseDate); r.Length() > 0 {
+		dates = append(dates, r.Text())
 	}
 
 	e.DOM.NextAllFiltered(":contains('ON-SALE'), :contains('FOC')").Each(func(_ int, p *goquery.Selection) {
 		p.Next().Find("li").Each(func(_ int, pe *goquery.Selection) {
 			if strings.EqualFold(normalizeTitle(pe.Text()), normalizeTitle(fullTitle)) {
 				if strings.Contains(pe.Text(), "ON SALE") {
-					cb.ReleaseDate = s.ex.ReleaseDate(ctx, pe.Text(), s.observer)
+					dates = append(dates, pe.Text())
 				} else {
-					cb.ReleaseDate = s.ex.ReleaseDate(ctx, p.Text(), s.observer)
+					dates = append(dates, p.Text())
 				}
 			}
 		})
 	})
 
-	if cb.ReleaseDate.IsZero() {
-		s.observer.OnError(s.ctx, slog.LevelWarn, "no release date found")
+	cb.ReleaseDate = s.releaseDate(ctx, dates)
+
+	if d := s.field(e, layout.Description); d.Length() > 0 {
+		cb.Description = collapseSpace(d.Text())
 	}
+
 	return cb
 }
 
+// releaseDate returns the first release date found in texts. A later text is only a fallback for the earlier ones,
+// so only the warnings of the last attempt are reported.
+func (s *comicReleasesScraper) releaseDate(ctx context.Context, texts []string) time.Time {
+	if len(texts) == 0 {
+		texts = []string{""}
+	}
+
+	for _, text := range texts[:len(texts)-1] {
+		if d := s.ex.ReleaseDate(ctx, text, discard{}); !d.IsZero() {
+			return d
+		}
+	}
+
+	return s.ex.ReleaseDate(ctx, texts[len(texts)-1], s.observer)
+}
+
+// discard drops the warnings it is given.
+type discard struct{}
+
+func (discard) OnError(context.Context, slog.Level, string, ...any) {}
+
+// pageLayout returns the configured layout, or the Comic Releases layout when none is configured.
+func (s *comicReleasesScraper) pageLayout() Layout {
+	if s.layout.Container == "" {
+		return DefaultDefinition().Layout
+	}
+
+	return s.layout
+}
+
+func (s *comicReleasesScraper) field(e *colly.HTMLElement, f Field) *goquery.Selection {
+	if f.Selector == "" {
+		return &goquery.Selection{}
+	}
+
+	return e.DOM.Children().Find(f.Selector).Eq(f.Index)
+}
+
 func normalizeTitle(s string) string {
 	s = strings.ToLower(s)
 	s = reBrackets.ReplaceAllString(s, "")
 	s = reAlphaNum.ReplaceAllString(s, "")
+	return collapseSpace(s)
+}
+
+// collapseSpace trims s and replaces every run of whitespace, including the line breaks of the markup, with a space.
+func collapseSpace(s string) string {
 	return strings.Join(strings.Fields(s), " ")
 }
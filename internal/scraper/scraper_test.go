@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/MikkelvtK/solipull/internal/models"
+	"github.com/MikkelvtK/solipull/internal/service"
 	"github.com/PuerkitoBio/goquery"
 	"github.com/gocolly/colly/v2"
 	"github.com/gocolly/colly/v2/queue"
@@ -15,6 +16,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -102,12 +104,12 @@ func setupDefaultScraper(ex ComicBookExtractor, t *testing.T) *comicReleasesScra
 
 	q, _ := queue.New(1, &queue.InMemoryQueueStorage{MaxSize: 10_000})
 
-	return &comicReleasesScraper{
-		navCol: colly.NewCollector(),
-		solCol: colly.NewCollector(),
-		queue:  q,
-		ex:     ex,
+	s, err := NewComicReleasesScraper(&SConfig{Nav: colly.NewCollector(), Sol: colly.NewCollector(), Q: q, Ex: ex})
+	if err != nil {
+		t.Fatalf("NewComicReleasesScraper() error = %v", err)
 	}
+
+	return s.(*comicReleasesScraper)
 }
 
 type MockExtractor struct {
@@ -148,8 +150,8 @@ func (m *MockExtractor) Publisher(ctx context.Context, s string, obs models.Erro
 	return args.String(0)
 }
 
-func (m *MockExtractor) Creators(node HTMLNode) []models.Creator {
-	args := m.Called(node)
+func (m *MockExtractor) Creators(ctx context.Context, node HTMLNode, obs models.ErrorObserver) []models.Creator {
+	args := m.Called(ctx, node, obs)
 	return args.Get(0).([]models.Creator)
 }
 
@@ -186,6 +188,22 @@ func (m *mockObserver) OnScrapingComplete() {
 	m.Called()
 }
 
+type mockPageObserver struct {
+	mockObserver
+}
+
+func (m *mockPageObserver) OnPageStart(url string) {
+	m.Called(url)
+}
+
+func (m *mockPageObserver) OnPageScraped(url string, books int) {
+	m.Called(url, books)
+}
+
+func (m *mockPageObserver) OnPageFailed(url string, err error) {
+	m.Called(url, err)
+}
+
 type MockQueryNode struct {
 	mock.Mock
 }
@@ -229,9 +247,9 @@ func Test_comicReleasesScraper_bindCallbacks(t *testing.T) {
 	navCol := colly.NewCollector()
 	solCol := colly.NewCollector()
 
-	s := &comicReleasesScraper{observer: mockObs, navCol: navCol, solCol: solCol}
+	s := &comicReleasesScraper{crawler: crawler{observer: mockObs, navCol: navCol, solCol: solCol}}
 
-	s.bindCallbacks(context.Background())
+	s.bindCallbacks()
 
 	mockObs.AssertExpectations(t)
 }
@@ -259,12 +277,17 @@ func Test_comicReleasesScraper_parseComicBookBatman(t *testing.T) {
 	mockEx.On("Issue", mock.Anything).Return("7")
 	mockEx.On("Pages", ctx, mock.Anything, mockObs).Return("32")
 	mockEx.On("Price", ctx, mock.Anything, mockObs).Return("5.99")
-	mockEx.On("Creators", mock.Anything).Return([]models.Creator{})
+	mockEx.On("Creators", ctx, mock.Anything, mockObs).Return([]models.Creator{})
 	mockEx.On("ReleaseDate", ctx, mock.Anything, mockObs).Return(time.Now())
 
-	s := &comicReleasesScraper{ex: mockEx, observer: mockObs}
+	s := &comicReleasesScraper{crawler: crawler{observer: mockObs}, ex: mockEx}
 
-	s.parseComicBook(context.Background(), el)
+	cb := s.parseComicBook(context.Background(), el)
+
+	if !strings.HasPrefix(cb.Description, "As Batman is beckoned to Arkham Towers") ||
+		strings.HasSuffix(cb.Description, " ") {
+		t.Errorf("parseComicBook() description = %q, want the trimmed solicitation text", cb.Description)
+	}
 
 	mockEx.AssertExpectations(t)
 	mockObs.AssertExpectations(t)
@@ -293,10 +316,11 @@ func Test_comicReleasesScraper_parseComicBookMarvel(t *testing.T) {
 	mockEx.On("Issue", mock.Anything).Return("")
 	mockEx.On("Pages", ctx, mock.Anything, mockObs).Return("")
 	mockEx.On("Price", ctx, mock.Anything, mockObs).Return("")
-	mockEx.On("Creators", mock.Anything).Return([]models.Creator{})
-	mockEx.On("ReleaseDate", ctx, mock.Anything, mockObs).Return(time.Time{})
+	mockEx.On("Creators", ctx, mock.Anything, mockObs).Return([]models.Creator{})
+	mockEx.On("ReleaseDate", ctx, mock.Anything, discard{}).Return(time.Time{})
+	mockEx.On("ReleaseDate", ctx, mock.Anything, mockObs).Once().Return(time.Time{})
 
-	s := &comicReleasesScraper{ex: mockEx, observer: mockObs}
+	s := &comicReleasesScraper{crawler: crawler{observer: mockObs}, ex: mockEx}
 
 	s.parseComicBook(context.Background(), el)
 
@@ -319,10 +343,17 @@ func TestNewComicReleasesScraper(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "valid config",
+			name: "missing collectors",
 			args: args{
 				cfg: &SConfig{},
 			},
+			wantErr: true,
+		},
+		{
+			name: "valid config",
+			args: args{
+				cfg: &SConfig{Nav: colly.NewCollector(), Sol: colly.NewCollector()},
+			},
 			wantErr: false,
 		},
 	}
@@ -365,6 +396,22 @@ func TestNewCollector(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "subdomain",
+			args: args{
+				domain:      "shop.example.com",
+				parallelism: 2,
+			},
+			wantErr: false,
+		},
+		{
+			name: "country code domain",
+			args: args{
+				domain:      "example.co.uk",
+				parallelism: 2,
+			},
+			wantErr: false,
+		},
 		{
 			name: "invalid domain",
 			args: args{
@@ -391,11 +438,35 @@ func TestNewCollector(t *testing.T) {
 	}
 }
 
+func TestNewCollector_URLFilters(t *testing.T) {
+	c, err := NewCollector("example.co.uk", 1)
+	if err != nil {
+		t.Fatalf("NewCollector() error = %v", err)
+	}
+
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{url: "https://example.co.uk/solicitations", want: true},
+		{url: "https://www.shop.example.co.uk", want: true},
+		{url: "https://notexample.co.uk", want: false},
+		{url: "https://example.com", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := c.URLFilters[0].MatchString(tt.url); got != tt.want {
+				t.Errorf("URLFilters match %s = %v, want %v", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_comicReleasesScraper_GetDataStarts(t *testing.T) {
 	ts := setupTestServer(`<html><body><a href="/comic/1">Link</a></body></html>`, t)
 	defer ts.Close()
 
-	ex := NewComicReleasesExtractor(nil)
+	ex := newTestExtractor(t)
 	scraper := setupDefaultScraper(ex, t)
 	results := make(chan models.ComicBook, 10)
 	ctx := context.Background()
@@ -415,7 +486,7 @@ func Test_comicReleasesScraper_GetDataAbortsWhenCancelledContext(t *testing.T) {
 	ts := setupTestServer(`<html><body><a href="/comic/1">Link</a></body></html>`, t)
 	defer ts.Close()
 
-	ex := NewComicReleasesExtractor(nil)
+	ex := newTestExtractor(t)
 	scraper := setupDefaultScraper(ex, t)
 	obs := &mockObserver{}
 	results := make(chan models.ComicBook, 10)
@@ -438,7 +509,7 @@ func Test_comicReleasesScraper_GetDataHandlesRequestError(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	ex := NewComicReleasesExtractor(nil)
+	ex := newTestExtractor(t)
 	scraper := setupDefaultScraper(ex, t)
 	obs := &mockObserver{}
 	results := make(chan models.ComicBook, 10)
@@ -466,7 +537,7 @@ func Test_comicReleasesScraper_GetDataScrapesComicBook(t *testing.T) {
 	tsLoc := setupTestServerXml(fmt.Sprintf(location, tsCb.URL, tsCb.URL), t)
 	defer tsLoc.Close()
 
-	ex := NewComicReleasesExtractor(nil)
+	ex := newTestExtractor(t)
 	obs := &mockObserver{}
 	results := make(chan models.ComicBook, 10)
 	ctx := context.Background()
@@ -488,3 +559,276 @@ func Test_comicReleasesScraper_GetDataScrapesComicBook(t *testing.T) {
 		t.Errorf("GetData failed: %v", err)
 	}
 }
+
+// fakeQueueStore is an in-memory QueueStore that records being cleared and the pages marked done.
+type fakeQueueStore struct {
+	queue.InMemoryQueueStorage
+	cleared bool
+	done    []string
+}
+
+func (f *fakeQueueStore) MarkDone(url string) error {
+	f.done = append(f.done, url)
+	return nil
+}
+
+func (f *fakeQueueStore) Clear() error {
+	f.cleared = true
+	return nil
+}
+
+func Test_comicReleasesScraper_PreviewKeepsQueue(t *testing.T) {
+	tsCb := setupTestServer(batmanHtml, t)
+	defer tsCb.Close()
+
+	tsLoc := setupTestServerXml(fmt.Sprintf(location, tsCb.URL, tsCb.URL), t)
+	defer tsLoc.Close()
+
+	store := &fakeQueueStore{InMemoryQueueStorage: queue.InMemoryQueueStorage{MaxSize: 10}}
+	q, _ := queue.New(1, store)
+	if err := q.AddURL(tsCb.URL + "/interrupted/"); err != nil {
+		t.Fatalf("AddURL failed: %v", err)
+	}
+
+	scraper := setupDefaultScraper(newTestExtractor(t), t)
+	scraper.queue, scraper.store = q, store
+	if err := scraper.SetInputs([]string{"march"}, []string{"dc"}); err != nil {
+		t.Fatalf("SetInputs failed: %v", err)
+	}
+
+	obs := &mockObserver{}
+	obs.On("OnStart").Once()
+	obs.On("OnUrlFound", 1).Once()
+	obs.On("OnNavigationComplete").Once()
+	obs.On("OnComicBookScraped", 1).Once()
+	obs.On("OnScrapingComplete").Once()
+
+	results := make(chan models.ComicBook, 10)
+	if err := scraper.Preview(context.Background(), tsLoc.URL, results, obs); err != nil {
+		t.Fatalf("Preview failed: %v", err)
+	}
+
+	if cb := <-results; cb.Title != "Batman" {
+		t.Errorf("expected Batman, got %v", cb.Title)
+	}
+
+	if n, _ := q.Size(); store.cleared || len(store.done) > 0 || n != 1 || scraper.queue != q {
+		t.Errorf("Preview touched the stored queue: cleared %v, done %v, %d queued", store.cleared, store.done, n)
+	}
+}
+
+func Test_comicReleasesScraper_RunsTwice(t *testing.T) {
+	tsCb := setupTestServer(batmanHtml, t)
+	defer tsCb.Close()
+
+	tsLoc := setupTestServerXml(fmt.Sprintf(location, tsCb.URL, tsCb.URL), t)
+	defer tsLoc.Close()
+
+	store := &fakeQueueStore{InMemoryQueueStorage: queue.InMemoryQueueStorage{MaxSize: 10}}
+	q, _ := queue.New(1, store)
+
+	scraper := setupDefaultScraper(newTestExtractor(t), t)
+	scraper.queue, scraper.store = q, store
+	if err := scraper.SetInputs([]string{"march"}, []string{"dc"}); err != nil {
+		t.Fatalf("SetInputs failed: %v", err)
+	}
+
+	obs := &mockObserver{}
+	obs.On("OnStart").Twice()
+	obs.On("OnUrlFound", 1).Twice()
+	obs.On("OnNavigationComplete").Twice()
+	obs.On("OnComicBookScraped", 1).Twice()
+	obs.On("OnScrapingComplete").Twice()
+
+	// A dry run followed by the sync itself, on the same provider.
+	runs := []func(results chan<- models.ComicBook) error{
+		func(results chan<- models.ComicBook) error {
+			return scraper.Preview(context.Background(), tsLoc.URL, results, obs)
+		},
+		func(results chan<- models.ComicBook) error {
+			return scraper.GetData(context.Background(), tsLoc.URL, results, obs)
+		},
+	}
+	for i, run := range runs {
+		results := make(chan models.ComicBook, 10)
+		if err := run(results); err != nil {
+			t.Fatalf("run %d failed: %v", i, err)
+		}
+
+		if n := len(results); n != 1 {
+			t.Errorf("run %d sent %d books, want 1", i, n)
+		}
+	}
+
+	if len(store.done) != 1 {
+		t.Errorf("marked %v done, want the page once", store.done)
+	}
+
+	obs.AssertExpectations(t)
+}
+
+func Test_comicReleasesScraper_ResumeRedirect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/dc-march-2026-solicitations/", http.RedirectHandler("/dc-march-2026-solicitations-2/",
+		http.StatusMovedPermanently))
+	mux.HandleFunc("/dc-march-2026-solicitations-2/", func(w http.ResponseWriter, _ *http.Request) {
+		if _, err := fmt.Fprintln(w, batmanHtml); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	store := &fakeQueueStore{InMemoryQueueStorage: queue.InMemoryQueueStorage{MaxSize: 10}}
+	q, _ := queue.New(1, store)
+	if err := q.AddURL(ts.URL + "/dc-march-2026-solicitations/"); err != nil {
+		t.Fatalf("AddURL failed: %v", err)
+	}
+
+	scraper := setupDefaultScraper(newTestExtractor(t), t)
+	scraper.queue, scraper.store = q, store
+
+	obs := &mockObserver{}
+	obs.On("OnStart").Once()
+	obs.On("OnUrlFound", 1).Once()
+	obs.On("OnNavigationComplete").Once()
+	obs.On("OnComicBookScraped", 1).Once()
+	obs.On("OnScrapingComplete").Once()
+
+	results := make(chan models.ComicBook, 10)
+	if err := scraper.Resume(context.Background(), results, obs); err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+
+	if want := []string{ts.URL + "/dc-march-2026-solicitations/"}; !reflect.DeepEqual(store.done, want) {
+		t.Errorf("Resume marked %v done, want the queued %v", store.done, want)
+	}
+}
+
+func Test_comicReleasesScraper_ResumeEmptyQueue(t *testing.T) {
+	ex := newTestExtractor(t)
+	scraper := setupDefaultScraper(ex, t)
+	obs := &mockObserver{}
+	results := make(chan models.ComicBook, 10)
+
+	obs.On("OnStart").Once()
+
+	if err := scraper.Resume(context.Background(), results, obs); !errors.Is(err, service.ErrNothingToResume) {
+		t.Errorf("expected ErrNothingToResume, got %v", err)
+	}
+
+	obs.AssertExpectations(t)
+}
+
+func Test_comicReleasesScraper_ResumeScrapesQueuedPages(t *testing.T) {
+	tsCb := setupTestServer(batmanHtml, t)
+	defer tsCb.Close()
+
+	ex := newTestExtractor(t)
+	scraper := setupDefaultScraper(ex, t)
+	obs := &mockObserver{}
+	results := make(chan models.ComicBook, 10)
+
+	if err := scraper.queue.AddURL(tsCb.URL + "/dc-march-2026-solicitations/"); err != nil {
+		t.Fatalf("AddURL failed: %v", err)
+	}
+
+	obs.On("OnStart").Once()
+	obs.On("OnUrlFound", 1).Once()
+	obs.On("OnNavigationComplete").Once()
+	obs.On("OnComicBookScraped", 1).Once()
+	obs.On("OnScrapingComplete").Once()
+
+	if err := scraper.Resume(context.Background(), results, obs); err != nil {
+		t.Errorf("Resume failed: %v", err)
+	}
+
+	if cb := <-results; cb.Title != "Batman" {
+		t.Errorf("expected Batman, got %v", cb.Title)
+	}
+
+	obs.AssertExpectations(t)
+}
+
+func Test_comicReleasesScraper_GetPages(t *testing.T) {
+	tsCb := setupTestServer(batmanHtml, t)
+	defer tsCb.Close()
+
+	tests := []struct {
+		name          string
+		publisher     string
+		wantPublisher string
+	}{
+		{
+			name:          "derives publisher from url",
+			publisher:     "",
+			wantPublisher: "dc",
+		},
+		{
+			name:          "uses explicit publisher",
+			publisher:     "image",
+			wantPublisher: "image",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scraper := setupDefaultScraper(newTestExtractor(t), t)
+			obs := &mockObserver{}
+			results := make(chan models.ComicBook, 10)
+
+			obs.On("OnStart").Once()
+			obs.On("OnUrlFound", 1).Once()
+			obs.On("OnNavigationComplete").Once()
+			obs.On("OnComicBookScraped", 1).Once()
+			obs.On("OnScrapingComplete").Once()
+
+			urls := []string{tsCb.URL + "/dc-march-2026-solicitations/"}
+			if err := scraper.GetPages(context.Background(), urls, tt.publisher, results, obs); err != nil {
+				t.Errorf("GetPages failed: %v", err)
+			}
+
+			if cb := <-results; cb.Publisher != tt.wantPublisher {
+				t.Errorf("GetPages publisher = %v, want %v", cb.Publisher, tt.wantPublisher)
+			}
+
+			obs.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_comicReleasesScraper_ReportsPages(t *testing.T) {
+	tsCb := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		fmt.Fprintln(w, batmanHtml)
+	}))
+	defer tsCb.Close()
+
+	scraper := setupDefaultScraper(newTestExtractor(t), t)
+	obs := &mockPageObserver{}
+	results := make(chan models.ComicBook, 10)
+
+	url := tsCb.URL + "/dc-march-2026-solicitations/"
+	missing := tsCb.URL + "/missing/"
+
+	obs.On("OnStart").Once()
+	obs.On("OnUrlFound", 2).Once()
+	obs.On("OnNavigationComplete").Once()
+	obs.On("OnComicBookScraped", 1).Once()
+	obs.On("OnScrapingComplete").Once()
+	obs.On("OnError", mock.Anything, slog.LevelError, mock.Anything, mock.Anything)
+	obs.On("OnPageStart", url).Once()
+	obs.On("OnPageStart", missing).Once()
+	obs.On("OnPageScraped", url, 1).Once()
+	obs.On("OnPageFailed", missing, mock.Anything).Once()
+
+	if err := scraper.GetPages(context.Background(), []string{url, missing}, "", results, obs); err != nil {
+		t.Errorf("GetPages failed: %v", err)
+	}
+
+	obs.AssertExpectations(t)
+}
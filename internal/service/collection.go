@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrBookNotFound  = errors.New("no stored book matches")
+	ErrAmbiguousBook = errors.New("more than one stored book matches")
+)
+
+var reSeriesDetails = regexp.MustCompile(`(?i)\s*\(\d{4}\)|\s+vol(?:ume)?\.?\s*\d+$`)
+
+// BookRef points at a stored book the way a user writes it, like "Batman #1". Publisher is optional and only needed
+// when the title and issue are not unique.
+type BookRef struct {
+	Title     string
+	Issue     string
+	Publisher string
+}
+
+// ParseBookRef splits a reference like "Batman #1" into its title and issue.
+func ParseBookRef(s, publisher string) BookRef {
+	ref := BookRef{Title: strings.TrimSpace(s), Publisher: strings.ToLower(strings.TrimSpace(publisher))}
+
+	if i := strings.LastIndex(s, "#"); i > 0 {
+		ref.Title = strings.TrimSpace(s[:i])
+		ref.Issue = strings.TrimSpace(s[i+1:])
+	}
+
+	return ref
+}
+
+func (r BookRef) String() string {
+	if r.Issue == "" {
+		return r.Title
+	}
+
+	return fmt.Sprintf("%s #%s", r.Title, r.Issue)
+}
+
+// CollectionService keeps track of the books on the pull list and in the collection.
+type CollectionService struct {
+	repo   models.CollectionRepository
+	series models.SeriesRepository
+}
+
+func NewCollectionService(r models.CollectionRepository, s models.SeriesRepository) *CollectionService {
+	return &CollectionService{repo: r, series: s}
+}
+
+// Add puts the book ref points at in the collection, replacing its entry when it is already there. The status
+// defaults to owned.
+func (c *CollectionService) Add(ctx context.Context, ref BookRef, item models.CollectionItem) (models.CollectionItem, error) {
+	if item.Status == "" {
+		item.Status = models.StatusOwned
+	}
+
+	if err := validateStatus(item.Status); err != nil {
+		return item, err
+	}
+
+	if item.PurchasePrice < 0 {
+		return item, errors.New("purchase price can not be negative")
+	}
+
+	cb, err := c.Find(ctx, ref)
+	if err != nil {
+		return item, err
+	}
+
+	item.ComicBook = cb
+	return item, c.repo.SaveItem(ctx, item)
+}
+
+func (c *CollectionService) Remove(ctx context.Context, ref BookRef) error {
+	cb, err := c.Find(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	return c.repo.RemoveItem(ctx, cb)
+}
+
+// SetStatus moves a book in the collection to another status, keeping the rest of its entry.
+func (c *CollectionService) SetStatus(ctx context.Context, ref BookRef, status string) error {
+	if err := validateStatus(status); err != nil {
+		return err
+	}
+
+	cb, err := c.Find(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	return c.repo.UpdateStatus(ctx, cb, status)
+}
+
+// Pull puts the books on the pull list as wanted and returns the ones it added. Books that are already in the
+// collection keep their entry.
+func (c *CollectionService) Pull(ctx context.Context, cbs []models.ComicBook) ([]models.ComicBook, error) {
+	items, err := c.repo.ListItems(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var added []models.ComicBook
+	for _, cb := range cbs {
+		if _, ok := findItem(items, cb); ok {
+			continue
+		}
+
+		if err := c.repo.SaveItem(ctx, models.CollectionItem{ComicBook: cb, Status: models.StatusWanted}); err != nil {
+			return added, err
+		}
+
+		added = append(added, cb)
+	}
+
+	return added, nil
+}
+
+// Unpull takes the books off the pull list and returns the ones it removed. Owned and read books stay in the
+// collection.
+func (c *CollectionService) Unpull(ctx context.Context, cbs []models.ComicBook) ([]models.ComicBook, error) {
+	items, err := c.repo.ListItems(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []models.ComicBook
+	for _, cb := range cbs {
+		if item, ok := findItem(items, cb); !ok || !item.Pulled() {
+			continue
+		}
+
+		if err := c.repo.RemoveItem(ctx, cb); err != nil {
+			return removed, err
+		}
+
+		removed = append(removed, cb)
+	}
+
+	return removed, nil
+}
+
+// findItem returns the collection entry of cb, matching books on their title, issue, publisher and release date.
+func findItem(items []models.CollectionItem, cb models.ComicBook) (models.CollectionItem, bool) {
+	if i := indexItem(items, cb); i >= 0 {
+		return items[i], true
+	}
+
+	return models.CollectionItem{}, false
+}
+
+func indexItem(items []models.CollectionItem, cb models.ComicBook) int {
+	return slices.IndexFunc(items, func(item models.CollectionItem) bool {
+		return item.Title == cb.Title && item.Issue == cb.Issue && strings.EqualFold(item.Publisher, cb.Publisher) &&
+			item.ReleaseDate.Equal(cb.ReleaseDate)
+	})
+}
+
+// List returns the collection, limited to status when it is set.
+func (c *CollectionService) List(ctx context.Context, status string) ([]models.CollectionItem, error) {
+	if status != "" {
+		if err := validateStatus(status); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.repo.ListItems(ctx, status)
+}
+
+// Find returns the single stored book ref points at.
+func (c *CollectionService) Find(ctx context.Context, ref BookRef) (models.ComicBook, error) {
+	if ref.Title == "" {
+		return models.ComicBook{}, errors.New("title is required")
+	}
+
+	cbs, err := c.repo.FindBooks(ctx, ref.Title, ref.Issue, ref.Publisher)
+	if err != nil {
+		return models.ComicBook{}, err
+	}
+
+	switch len(cbs) {
+	case 0:
+		return models.ComicBook{}, fmt.Errorf("%w %s", ErrBookNotFound, ref)
+	case 1:
+		return cbs[0], nil
+	}
+
+	matches := make([]string, 0, len(cbs))
+	for _, cb := range cbs {
+		matches = append(matches, fmt.Sprintf("%s (%s)", strings.ToUpper(cb.Publisher), formatDate(cb)))
+	}
+
+	return models.ComicBook{}, fmt.Errorf("%w %s: %s", ErrAmbiguousBook, ref, strings.Join(matches, ", "))
+}
+
+func validateStatus(status string) error {
+	if !slices.Contains(models.CollectionStatuses, status) {
+		return fmt.Errorf("unknown status %q, expected one of: %s", status,
+			strings.Join(models.CollectionStatuses, ", "))
+	}
+
+	return nil
+}
+
+// ImportResult reports how the rows of an import were matched against the stored books. Unmatched also holds the
+// invalid rows.
+type ImportResult struct {
+	Imported  int
+	Unmatched []UnmatchedRow
+}
+
+type UnmatchedRow struct {
+	Row    models.ImportRow
+	Reason string
+}
+
+// Import matches rows against the stored books by title, issue and publisher and adds the matched books to the
+// collection. A title with a start year or volume, like "Batman (2025)", also matches the plain title and a
+// publisher like "DC Comics" matches "dc". Books that are already in the collection are merged with mergeItem.
+func (c *CollectionService) Import(ctx context.Context, rows []models.ImportRow) (ImportResult, error) {
+	var res ImportResult
+
+	items, err := c.repo.ListItems(ctx, "")
+	if err != nil {
+		return res, err
+	}
+
+	for _, row := range rows {
+		if row.Invalid != "" {
+			res.Unmatched = append(res.Unmatched, UnmatchedRow{Row: row, Reason: row.Invalid})
+			continue
+		}
+
+		publisher := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(row.Publisher)), " comics")
+
+		ref := BookRef{Title: row.Title, Issue: row.Issue, Publisher: publisher}
+		if ref.Issue == "" {
+			ref = ParseBookRef(row.Title, publisher)
+		}
+
+		cb, err := c.Find(ctx, ref)
+		if plain := reSeriesDetails.ReplaceAllString(ref.Title, ""); errors.Is(err, ErrBookNotFound) && plain != ref.Title {
+			ref.Title = plain
+			cb, err = c.Find(ctx, ref)
+		}
+
+		if errors.Is(err, ErrBookNotFound) || errors.Is(err, ErrAmbiguousBook) {
+			res.Unmatched = append(res.Unmatched, UnmatchedRow{Row: row, Reason: err.Error()})
+			continue
+		}
+		if err != nil {
+			return res, err
+		}
+
+		item := row.Item
+		item.ComicBook = cb
+		if err := validateStatus(item.Status); err != nil {
+			res.Unmatched = append(res.Unmatched, UnmatchedRow{Row: row, Reason: err.Error()})
+			continue
+		}
+
+		if i := indexItem(items, cb); i >= 0 {
+			item = mergeItem(items[i], item)
+			items[i] = item
+		} else {
+			items = append(items, item)
+		}
+
+		if err := c.repo.SaveItem(ctx, item); err != nil {
+			return res, err
+		}
+
+		res.Imported++
+	}
+
+	return res, nil
+}
+
+// mergeItem fills in the empty fields of an item that is already in the collection from an imported one. The status
+// only moves from the pull list to owned or read, so importing a pull list never makes owned books wanted again.
+func mergeItem(item, imported models.CollectionItem) models.CollectionItem {
+	if item.Pulled() && imported.Owned() {
+		item.Status = imported.Status
+	}
+
+	if item.Condition == "" {
+		item.Condition = imported.Condition
+	}
+
+	if item.PurchasePrice == 0 {
+		item.PurchasePrice = imported.PurchasePrice
+	}
+
+	if item.PurchaseDate.IsZero() {
+		item.PurchaseDate = imported.PurchaseDate
+	}
+
+	if item.Location == "" {
+		item.Location = imported.Location
+	}
+
+	return item
+}
@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeCollection struct {
+	books   []models.ComicBook
+	saved   []models.CollectionItem
+	removed []models.ComicBook
+}
+
+func (f *fakeCollection) FindBooks(_ context.Context, title, issue, publisher string) ([]models.ComicBook, error) {
+	var cbs []models.ComicBook
+	for _, cb := range f.books {
+		if cb.Title == title && cb.Issue == issue && (publisher == "" || cb.Publisher == publisher) {
+			cbs = append(cbs, cb)
+		}
+	}
+
+	return cbs, nil
+}
+
+func (f *fakeCollection) SaveItem(_ context.Context, item models.CollectionItem) error {
+	f.saved = append(f.saved, item)
+	return nil
+}
+
+func (f *fakeCollection) UpdateStatus(context.Context, models.ComicBook, string) error {
+	return nil
+}
+
+func (f *fakeCollection) RemoveItem(_ context.Context, cb models.ComicBook) error {
+	f.removed = append(f.removed, cb)
+	return nil
+}
+
+func (f *fakeCollection) ListItems(context.Context, string) ([]models.CollectionItem, error) {
+	return f.saved, nil
+}
+
+func TestParseBookRef(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         string
+		publisher string
+		want      BookRef
+	}{
+		{
+			name: "title and issue",
+			s:    "Batman #1",
+			want: BookRef{Title: "Batman", Issue: "1"},
+		},
+		{
+			name:      "publisher",
+			s:         "Saga #80",
+			publisher: "Image",
+			want:      BookRef{Title: "Saga", Issue: "80", Publisher: "image"},
+		},
+		{
+			name: "# in the title",
+			s:    "#DRCL Midnight Children #5",
+			want: BookRef{Title: "#DRCL Midnight Children", Issue: "5"},
+		},
+		{
+			name: "no issue",
+			s:    "Monstress Vol. 10 Tp",
+			want: BookRef{Title: "Monstress Vol. 10 Tp"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseBookRef(tt.s, tt.publisher); got != tt.want {
+				t.Errorf("ParseBookRef() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollectionService_Add(t *testing.T) {
+	release := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	repo := &fakeCollection{books: []models.ComicBook{
+		{Title: "Batman", Issue: "1", Publisher: "dc", ReleaseDate: release},
+		{Title: "Batman", Issue: "1", Publisher: "image", ReleaseDate: release},
+		{Title: "Saga", Issue: "80", Publisher: "image", ReleaseDate: release},
+	}}
+	s := NewCollectionService(repo, nil)
+
+	tests := []struct {
+		name       string
+		ref        BookRef
+		item       models.CollectionItem
+		wantStatus string
+		wantErr    error
+	}{
+		{
+			name:       "defaults to owned",
+			ref:        BookRef{Title: "Saga", Issue: "80"},
+			wantStatus: models.StatusOwned,
+		},
+		{
+			name:       "keeps status",
+			ref:        BookRef{Title: "Batman", Issue: "1", Publisher: "dc"},
+			item:       models.CollectionItem{Status: models.StatusWanted},
+			wantStatus: models.StatusWanted,
+		},
+		{
+			name:    "ambiguous",
+			ref:     BookRef{Title: "Batman", Issue: "1"},
+			wantErr: ErrAmbiguousBook,
+		},
+		{
+			name:    "not found",
+			ref:     BookRef{Title: "Batman", Issue: "2"},
+			wantErr: ErrBookNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Add(context.Background(), tt.ref, tt.item)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got.Status != tt.wantStatus || got.Title != tt.ref.Title {
+				t.Errorf("Add() got = %+v, want status %s for %s", got, tt.wantStatus, tt.ref)
+			}
+		})
+	}
+
+	if _, err := s.Add(context.Background(), BookRef{Title: "Saga", Issue: "80"}, models.CollectionItem{Status: "lost"}); err == nil {
+		t.Errorf("Add() expected error for unknown status")
+	}
+}
+
+func TestCollectionService_Import(t *testing.T) {
+	release := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	repo := &fakeCollection{books: []models.ComicBook{
+		{Title: "Batman", Issue: "1", Publisher: "dc", ReleaseDate: release},
+		{Title: "Batman", Issue: "1", Publisher: "image", ReleaseDate: release},
+		{Title: "Saga", Issue: "80", Publisher: "image", ReleaseDate: release},
+	}}
+	s := NewCollectionService(repo, nil)
+
+	rows := []models.ImportRow{
+		{Line: 2, Title: "Saga", Issue: "80", Item: models.CollectionItem{Status: models.StatusOwned}},
+		{Line: 3, Title: "Batman (2025)", Issue: "1", Publisher: "DC Comics", Item: models.CollectionItem{Status: models.StatusWanted}},
+		{Line: 4, Title: "Batman #1", Item: models.CollectionItem{Status: models.StatusWanted}},
+		{Line: 5, Title: "Saga", Issue: "99", Item: models.CollectionItem{Status: models.StatusOwned}},
+		{Line: 6, Title: "Saga", Issue: "80", Invalid: `invalid price "free"`},
+	}
+
+	got, err := s.Import(context.Background(), rows)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	if got.Imported != 2 {
+		t.Errorf("Import() imported = %d, want 2", got.Imported)
+	}
+
+	var lines []int
+	for _, u := range got.Unmatched {
+		lines = append(lines, u.Row.Line)
+	}
+
+	if len(lines) != 3 || lines[0] != 4 || lines[1] != 5 || lines[2] != 6 {
+		t.Errorf("Import() unmatched lines = %v, want [4 5 6]", lines)
+	}
+
+	if got.Unmatched[2].Reason != `invalid price "free"` {
+		t.Errorf("Import() reason = %q, want the invalid price", got.Unmatched[2].Reason)
+	}
+
+	if !strings.HasPrefix(got.Unmatched[0].Reason, ErrAmbiguousBook.Error()) {
+		t.Errorf("Import() reason = %q, want an ambiguous match", got.Unmatched[0].Reason)
+	}
+
+	if repo.saved[1].Publisher != "dc" || repo.saved[1].Status != models.StatusWanted {
+		t.Errorf("Import() saved = %+v, want the dc book as wanted", repo.saved[1])
+	}
+}
+
+func TestCollectionService_Import_Existing(t *testing.T) {
+	release := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	saga := models.ComicBook{Title: "Saga", Issue: "80", Publisher: "image", ReleaseDate: release}
+	batman := models.ComicBook{Title: "Batman", Issue: "1", Publisher: "dc", ReleaseDate: release}
+	bought := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		existing models.CollectionItem
+		row      models.ImportRow
+		want     models.CollectionItem
+	}{
+		{
+			name: "pull list over an owned book",
+			existing: models.CollectionItem{ComicBook: saga, Status: models.StatusOwned, Condition: "NM",
+				PurchasePrice: 3.99, PurchaseDate: bought, Location: "Box 1"},
+			row: models.ImportRow{Title: "Saga", Issue: "80", Item: models.CollectionItem{Status: models.StatusWanted}},
+			want: models.CollectionItem{ComicBook: saga, Status: models.StatusOwned, Condition: "NM",
+				PurchasePrice: 3.99, PurchaseDate: bought, Location: "Box 1"},
+		},
+		{
+			name:     "collection over a pulled book",
+			existing: models.CollectionItem{ComicBook: batman, Status: models.StatusWanted, Location: "Shelf"},
+			row: models.ImportRow{Title: "Batman", Issue: "1", Item: models.CollectionItem{Status: models.StatusRead,
+				Condition: "VF", PurchasePrice: 4.99, Location: "Box 2"}},
+			want: models.CollectionItem{ComicBook: batman, Status: models.StatusRead, Condition: "VF",
+				PurchasePrice: 4.99, Location: "Shelf"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeCollection{
+				books: []models.ComicBook{saga, batman},
+				saved: []models.CollectionItem{tt.existing},
+			}
+			s := NewCollectionService(repo, nil)
+
+			got, err := s.Import(context.Background(), []models.ImportRow{tt.row})
+			if err != nil || got.Imported != 1 {
+				t.Fatalf("Import() = %+v, %v, want 1 imported", got, err)
+			}
+
+			if last := repo.saved[len(repo.saved)-1]; !reflect.DeepEqual(last, tt.want) {
+				t.Errorf("Import() saved = %+v, want %+v", last, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollectionService_Pull(t *testing.T) {
+	release := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	saga := models.ComicBook{Title: "Saga", Issue: "80", Publisher: "image", ReleaseDate: release}
+	batman := models.ComicBook{Title: "Batman", Issue: "1", Publisher: "dc", ReleaseDate: release}
+	monstress := models.ComicBook{Title: "Monstress", Issue: "1", Publisher: "image", ReleaseDate: release}
+
+	repo := &fakeCollection{saved: []models.CollectionItem{
+		{ComicBook: saga, Status: models.StatusOwned},
+		{ComicBook: batman, Status: models.StatusOrdered},
+	}}
+	s := NewCollectionService(repo, nil)
+
+	added, err := s.Pull(context.Background(), []models.ComicBook{saga, batman, monstress})
+	if err != nil {
+		t.Fatalf("Pull() error = %v", err)
+	}
+
+	if len(added) != 1 || added[0].Title != "Monstress" {
+		t.Errorf("Pull() added = %v, want only Monstress", added)
+	}
+
+	if last := repo.saved[len(repo.saved)-1]; last.Title != "Monstress" || last.Status != models.StatusWanted {
+		t.Errorf("Pull() saved = %+v, want Monstress as wanted", last)
+	}
+
+	removed, err := s.Unpull(context.Background(), []models.ComicBook{saga, batman, monstress})
+	if err != nil {
+		t.Fatalf("Unpull() error = %v", err)
+	}
+
+	var titles []string
+	for _, cb := range removed {
+		titles = append(titles, cb.Title)
+	}
+
+	if strings.Join(titles, ",") != "Batman,Monstress" || len(repo.removed) != 2 {
+		t.Errorf("Unpull() removed = %v, want Batman and Monstress but not the owned Saga", titles)
+	}
+}
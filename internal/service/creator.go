@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"slices"
+	"strings"
+	"time"
+)
+
+// CreatorService manages the identities behind creator credits, so the spellings a name appears under on
+// different solicitation pages are treated as one person.
+type CreatorService struct {
+	people  models.PersonRepository
+	follows models.FollowRepository
+}
+
+func NewCreatorService(p models.PersonRepository, f models.FollowRepository) *CreatorService {
+	return &CreatorService{people: p, follows: f}
+}
+
+func (c *CreatorService) List(ctx context.Context, search string) ([]models.Person, error) {
+	return c.people.ListPeople(ctx, search)
+}
+
+// Alias makes future credits under alias resolve to the creator known as name.
+func (c *CreatorService) Alias(ctx context.Context, name, alias string) error {
+	if strings.TrimSpace(name) == "" || strings.TrimSpace(alias) == "" {
+		return errors.New("name and alias are required")
+	}
+
+	return c.people.AddAlias(ctx, name, alias)
+}
+
+// Merge folds the creator known as from into the creator known as into, keeping the name of into.
+func (c *CreatorService) Merge(ctx context.Context, from, into string) error {
+	if strings.TrimSpace(from) == "" || strings.TrimSpace(into) == "" {
+		return errors.New("both creators are required")
+	}
+
+	return c.people.MergePeople(ctx, from, into)
+}
+
+// Follow tracks the books of the creator known as name, limited to roles when any are given.
+func (c *CreatorService) Follow(ctx context.Context, name string, roles []string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("name is required")
+	}
+
+	normalized := make([]string, 0, len(roles))
+	for _, r := range roles {
+		r = strings.ToLower(strings.TrimSpace(r))
+		if !slices.Contains(models.CreatorRoles, r) {
+			return fmt.Errorf("unknown role %q, expected one of: %s", r, strings.Join(models.CreatorRoles, ", "))
+		}
+
+		if !slices.Contains(normalized, r) {
+			normalized = append(normalized, r)
+		}
+	}
+
+	return c.follows.Follow(ctx, name, normalized)
+}
+
+func (c *CreatorService) Unfollow(ctx context.Context, name string) error {
+	return c.follows.Unfollow(ctx, name)
+}
+
+func (c *CreatorService) Following(ctx context.Context) ([]models.Follow, error) {
+	return c.follows.ListFollows(ctx)
+}
+
+// Upcoming returns the stored books released in [from, to) that feature followed creators.
+func (c *CreatorService) Upcoming(ctx context.Context, from, to time.Time) ([]models.ComicBook, error) {
+	if !to.After(from) {
+		return nil, errors.New("end of the date range must be after the start")
+	}
+
+	return c.follows.FollowedBooks(ctx, models.FollowedBooksQuery{From: from, To: to})
+}
+
+// NewlySolicited returns the books featuring followed creators that were first stored at or after since.
+func (c *CreatorService) NewlySolicited(ctx context.Context, since time.Time) ([]models.ComicBook, error) {
+	return c.follows.FollowedBooks(ctx, models.FollowedBooksQuery{AddedSince: since})
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"io"
+	"iter"
+	"time"
+)
+
+var (
+	ErrNotPrepared       = errors.New("the database is not migrated")
+	ErrNothingToRollBack = errors.New("no migrations are applied")
+	ErrNoRetention       = errors.New("no retention given")
+)
+
+// DatabaseService migrates, checks and backs up the database and moves its data in and out as a portable dump.
+type DatabaseService struct {
+	maint      models.DatabaseMaintenance
+	books      models.ComicBookRepository
+	people     models.PersonRepository
+	follows    models.FollowRepository
+	collection models.CollectionRepository
+	series     models.SeriesRepository
+	dumps      models.DumpRepository
+	retention  models.Retention
+
+	prepared   error
+	onPrepared func(ctx context.Context) error
+}
+
+func NewDatabaseService(m models.DatabaseMaintenance, b models.ComicBookRepository, p models.PersonRepository,
+	f models.FollowRepository, c models.CollectionRepository, s models.SeriesRepository, dumps models.DumpRepository,
+	r models.Retention) *DatabaseService {
+	return &DatabaseService{maint: m, books: b, people: p, follows: f, collection: c, series: s, dumps: dumps,
+		retention: r, prepared: ErrNotPrepared}
+}
+
+// OnPrepared sets what to run once the database is prepared, like setting up what keeps its state in the database.
+func (d *DatabaseService) OnPrepared(f func(ctx context.Context) error) {
+	d.onPrepared = f
+}
+
+// Prepare brings the schema up to date and links credits and books stored by older builds to their people and
+// series. It is run by the commands that use the data, not at startup, so the db commands see and keep the schema as
+// it is. Its outcome is kept, so it only runs once.
+func (d *DatabaseService) Prepare(ctx context.Context) error {
+	if !errors.Is(d.prepared, ErrNotPrepared) {
+		return d.prepared
+	}
+
+	d.prepared = d.prepare(ctx)
+	return d.prepared
+}
+
+func (d *DatabaseService) prepare(ctx context.Context) error {
+	if _, err := d.maint.Migrate(ctx); err != nil {
+		return err
+	}
+
+	if _, err := d.people.LinkPeople(ctx); err != nil {
+		return fmt.Errorf("failed to link people: %v", err)
+	}
+
+	if _, err := d.series.LinkSeries(ctx); err != nil {
+		return fmt.Errorf("failed to link series: %v", err)
+	}
+
+	if d.onPrepared != nil {
+		return d.onPrepared(ctx)
+	}
+
+	return nil
+}
+
+func (d *DatabaseService) Migrations(ctx context.Context) ([]models.Migration, error) {
+	return d.maint.Migrations(ctx)
+}
+
+// MigrateUp applies all pending migrations and returns the path of the backup made before, if any.
+func (d *DatabaseService) MigrateUp(ctx context.Context) (string, error) {
+	return d.maint.Migrate(ctx)
+}
+
+// MigrateDown rolls back the newest applied migration and returns the path of the backup made before.
+func (d *DatabaseService) MigrateDown(ctx context.Context) (string, error) {
+	migrations, err := d.maint.Migrations(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var current, previous int64
+	for _, m := range migrations {
+		if m.Applied {
+			previous, current = current, m.Version
+		}
+	}
+
+	if current == 0 {
+		return "", ErrNothingToRollBack
+	}
+
+	return d.maint.MigrateTo(ctx, previous)
+}
+
+// MigrateTo applies or rolls back migrations until the schema is at version, and returns the path of the backup
+// made before, if any.
+func (d *DatabaseService) MigrateTo(ctx context.Context, version int64) (string, error) {
+	return d.maint.MigrateTo(ctx, version)
+}
+
+// Prune deletes the books released longer than r before now, keeping the ones in the collection or on the pull list.
+// On a dry run nothing is deleted.
+func (d *DatabaseService) Prune(ctx context.Context, r models.Retention, now time.Time,
+	dryRun bool) (models.PruneSummary, error) {
+	if r.IsZero() {
+		return models.PruneSummary{}, ErrNoRetention
+	}
+
+	return d.books.Prune(ctx, r.Cutoff(now), dryRun)
+}
+
+// Retention returns the retention policy from the config, which is zero when books are kept forever.
+func (d *DatabaseService) Retention() models.Retention {
+	return d.retention
+}
+
+// ApplyRetention prunes the books that fall outside the retention policy from the config. Without a policy nothing
+// is deleted.
+func (d *DatabaseService) ApplyRetention(ctx context.Context, now time.Time) (models.PruneSummary, error) {
+	if d.retention.IsZero() {
+		return models.PruneSummary{}, nil
+	}
+
+	return d.Prune(ctx, d.retention, now, false)
+}
+
+func (d *DatabaseService) Check(ctx context.Context) (models.IntegrityReport, error) {
+	return d.maint.Check(ctx)
+}
+
+// Vacuum reclaims the space of deleted rows and returns the size of the database before and after in bytes.
+func (d *DatabaseService) Vacuum(ctx context.Context) (int64, int64, error) {
+	return d.maint.Vacuum(ctx)
+}
+
+// Backup copies the database to dst, or to a timestamped file next to it when dst is empty, and returns the path of
+// the copy.
+func (d *DatabaseService) Backup(ctx context.Context, dst string) (string, error) {
+	return d.maint.Backup(ctx, dst)
+}
+
+func (d *DatabaseService) Restore(ctx context.Context, src string) error {
+	return d.maint.Restore(ctx, src)
+}
+
+// Dump writes everything a user would not want to lose to w as a JSON dump. The books are streamed in release order,
+// so a large database is never held in memory.
+func (d *DatabaseService) Dump(ctx context.Context, w io.Writer) error {
+	dump := models.Dump{Version: models.DumpVersion, CreatedAt: time.Now().UTC()}
+
+	var err error
+	if dump.People, err = d.people.ListPeople(ctx, ""); err != nil {
+		return err
+	}
+
+	if dump.Follows, err = d.follows.ListFollows(ctx); err != nil {
+		return err
+	}
+
+	if dump.Collection, err = d.collection.ListItems(ctx, ""); err != nil {
+		return err
+	}
+
+	return writeDump(w, dump, d.books.Books(ctx, models.BookQuery{}))
+}
+
+// writeDump writes dump as an indented JSON object with the books taken from books instead of dump.ComicBooks. The
+// books come last and are encoded one at a time, so they are never held in memory.
+func writeDump(w io.Writer, dump models.Dump, books iter.Seq2[models.ComicBook, error]) error {
+	bw := bufio.NewWriter(w)
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+
+	// encode writes v without the newline the encoder ends it with.
+	encode := func(v any, indent string) error {
+		buf.Reset()
+		enc.SetIndent(indent, "  ")
+
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+
+		bw.Write(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+		return nil
+	}
+
+	header := []struct {
+		key   string
+		value any
+	}{
+		{key: "version", value: dump.Version},
+		{key: "created_at", value: dump.CreatedAt},
+		{key: "people", value: dump.People},
+		{key: "follows", value: dump.Follows},
+		{key: "collection", value: dump.Collection},
+	}
+
+	bw.WriteString("{")
+	for _, h := range header {
+		fmt.Fprintf(bw, "\n  %q: ", h.key)
+		if err := encode(h.value, "  "); err != nil {
+			return err
+		}
+		bw.WriteString(",")
+	}
+
+	bw.WriteString(`
+  "comic_books": [`)
+
+	n := 0
+	for cb, err := range books {
+		if err != nil {
+			return err
+		}
+
+		if n > 0 {
+			bw.WriteString(",")
+		}
+
+		bw.WriteString("\n    ")
+		if err := encode(cb, "    "); err != nil {
+			return err
+		}
+		n++
+	}
+
+	if n > 0 {
+		bw.WriteString("\n  ")
+	}
+
+	bw.WriteString("]\n}\n")
+
+	return bw.Flush()
+}
+
+// Load merges a dump into the database after backing it up, and returns the path of the backup. The dump is loaded in
+// a single transaction, so when it fails the database is left as it was.
+func (d *DatabaseService) Load(ctx context.Context, dump models.Dump) (string, error) {
+	if dump.Version < 1 || dump.Version > models.DumpVersion {
+		return "", fmt.Errorf("unsupported dump version %d, this build reads up to version %d", dump.Version,
+			models.DumpVersion)
+	}
+
+	backup, err := d.maint.Backup(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to back up the database before loading: %v", err)
+	}
+
+	return backup, d.dumps.LoadDump(ctx, dump)
+}
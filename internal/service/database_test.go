@@ -0,0 +1,182 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeMaintenance struct {
+	backups    int
+	migrations int
+	migratedTo int64
+}
+
+func (f *fakeMaintenance) Backup(context.Context, string) (string, error) {
+	f.backups++
+	return "backup.db", nil
+}
+
+func (f *fakeMaintenance) Restore(context.Context, string) error {
+	return nil
+}
+
+func (f *fakeMaintenance) Migrations(context.Context) ([]models.Migration, error) {
+	return []models.Migration{
+		{Version: 1, Applied: true},
+		{Version: 2, Applied: true},
+		{Version: 3},
+	}, nil
+}
+
+func (f *fakeMaintenance) Migrate(context.Context) (string, error) {
+	f.migrations++
+	return "", nil
+}
+
+func (f *fakeMaintenance) MigrateTo(_ context.Context, version int64) (string, error) {
+	f.migratedTo = version
+	return "backup.db", nil
+}
+
+func (f *fakeMaintenance) Check(context.Context) (models.IntegrityReport, error) {
+	return models.IntegrityReport{}, nil
+}
+
+func (f *fakeMaintenance) Vacuum(context.Context) (int64, int64, error) {
+	return 0, 0, nil
+}
+
+func TestDatabaseService_Load_Version(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int
+	}{
+		{name: "missing version", version: 0},
+		{name: "newer version", version: models.DumpVersion + 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &fakeMaintenance{}
+			d := NewDatabaseService(m, nil, nil, nil, nil, nil, nil, models.Retention{})
+
+			if _, err := d.Load(context.Background(), models.Dump{Version: tt.version}); err == nil {
+				t.Errorf("Load() expected error for version %d", tt.version)
+			}
+
+			if m.backups != 0 {
+				t.Errorf("Load() made a backup for a dump it can not load")
+			}
+		})
+	}
+}
+
+func TestDatabaseService_MigrateDown(t *testing.T) {
+	m := &fakeMaintenance{migratedTo: -1}
+	d := NewDatabaseService(m, nil, nil, nil, nil, nil, nil, models.Retention{})
+
+	if _, err := d.MigrateDown(context.Background()); err != nil {
+		t.Fatalf("MigrateDown() error = %v", err)
+	}
+
+	if m.migratedTo != 1 {
+		t.Errorf("MigrateDown() migrated to %d, want 1", m.migratedTo)
+	}
+}
+
+type fakeSeries struct {
+	models.SeriesRepository
+	linked int
+}
+
+func (f *fakeSeries) LinkSeries(context.Context) (int, error) {
+	f.linked++
+	return 0, nil
+}
+
+type linkedPeople struct {
+	fakePeople
+	linked int
+}
+
+func (f *linkedPeople) LinkPeople(context.Context) (int, error) {
+	f.linked++
+	return 0, nil
+}
+
+func TestDatabaseService_Prepare(t *testing.T) {
+	m := &fakeMaintenance{}
+	p := &linkedPeople{}
+	s := &fakeSeries{}
+	d := NewDatabaseService(m, nil, p, nil, nil, s, nil, models.Retention{})
+
+	prepared := 0
+	d.OnPrepared(func(context.Context) error {
+		prepared++
+		return nil
+	})
+
+	if m.migrations != 0 {
+		t.Fatalf("NewDatabaseService() migrated before Prepare")
+	}
+
+	for range 2 {
+		if err := d.Prepare(context.Background()); err != nil {
+			t.Fatalf("Prepare() error = %v", err)
+		}
+	}
+
+	if m.migrations != 1 || p.linked != 1 || s.linked != 1 || prepared != 1 {
+		t.Errorf("Prepare() migrated %d, linked people %d, linked series %d and prepared %d times, want once",
+			m.migrations, p.linked, s.linked, prepared)
+	}
+}
+
+func TestWriteDump(t *testing.T) {
+	dump := models.Dump{
+		Version:   models.DumpVersion,
+		CreatedAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		ComicBooks: []models.ComicBook{
+			{Title: "Saga", Issue: "1", Creators: []models.Creator{{Name: "Fiona Staples", Role: "artist"}}},
+			{Title: "Batman", Issue: "2"},
+		},
+		People:  []models.Person{{Name: "Fiona Staples", Aliases: []string{"fiona staples"}}},
+		Follows: []models.Follow{{Name: "Fiona Staples"}},
+	}
+
+	books := func(yield func(models.ComicBook, error) bool) {
+		for _, cb := range dump.ComicBooks {
+			if !yield(cb, nil) {
+				return
+			}
+		}
+	}
+
+	var got bytes.Buffer
+	if err := writeDump(&got, dump, books); err != nil {
+		t.Fatalf("writeDump() error = %v", err)
+	}
+
+	var loaded models.Dump
+	if err := json.Unmarshal(got.Bytes(), &loaded); err != nil {
+		t.Fatalf("writeDump() wrote invalid JSON: %v\n%s", err, got.String())
+	}
+
+	if !reflect.DeepEqual(loaded, dump) {
+		t.Errorf("writeDump() got = %+v, want %+v", loaded, dump)
+	}
+
+	got.Reset()
+	if err := writeDump(&got, models.Dump{Version: models.DumpVersion}, func(func(models.ComicBook, error) bool) {}); err != nil {
+		t.Fatalf("writeDump() error = %v", err)
+	}
+
+	if !strings.HasSuffix(got.String(), "\"comic_books\": []\n}\n") {
+		t.Errorf("writeDump() without books got = %s, want an empty comic_books array at the end", got.String())
+	}
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"github.com/MikkelvtK/solipull/internal/models"
+	"slices"
+	"strings"
+)
+
+// comicBookKey identifies a book across syncs the way the database does: by title, issue, publisher and release
+// date. A book with a moved release date is stored as a new book, so it shows up as new and removed.
+func comicBookKey(cb models.ComicBook) string {
+	return strings.Join([]string{cb.Publisher, cb.Title, cb.Issue, formatDate(cb)}, "|")
+}
+
+// resolveCreators renames the credits of the books to the person they resolve to, like the database does when they
+// are saved, so a credit under an alias is not a change. The books are copied, not changed.
+func resolveCreators(cbs []models.ComicBook, people []models.Person) []models.ComicBook {
+	names := make(map[string]string)
+	for _, p := range people {
+		for _, alias := range p.Aliases {
+			names[alias] = p.Name
+		}
+	}
+
+	resolved := make([]models.ComicBook, 0, len(cbs))
+	for _, cb := range cbs {
+		if len(cb.Creators) == 0 {
+			resolved = append(resolved, cb)
+			continue
+		}
+
+		creators := make([]models.Creator, 0, len(cb.Creators))
+		for _, c := range cb.Creators {
+			if name, ok := names[models.PersonKey(c.Name)]; ok {
+				c.Name = name
+			} else {
+				c.Name = strings.TrimSpace(c.Name)
+			}
+
+			creators = append(creators, c)
+		}
+
+		cb.Creators = creators
+		resolved = append(resolved, cb)
+	}
+
+	return resolved
+}
+
+func diffComicBooks(stored, scraped []models.ComicBook) *models.SyncDiff {
+	diff := &models.SyncDiff{}
+
+	old := make(map[string]models.ComicBook, len(stored))
+	for _, cb := range stored {
+		old[comicBookKey(cb)] = cb
+	}
+
+	seen := make(map[string]bool, len(scraped))
+	for _, cb := range scraped {
+		k := comicBookKey(cb)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+
+		o, ok := old[k]
+		if !ok {
+			diff.New = append(diff.New, cb)
+			continue
+		}
+
+		if fields := diffFields(o, cb); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, models.ComicBookChange{ComicBook: cb, Fields: fields})
+		}
+	}
+
+	for _, cb := range stored {
+		if !seen[comicBookKey(cb)] {
+			diff.Removed = append(diff.Removed, cb)
+		}
+	}
+
+	return diff
+}
+
+// diffFields lists the fields a sync would update. Like the database, it keeps the stored description, source and url
+// when none were found, while the credits are always replaced. Pages, format and price are never updated.
+func diffFields(o, n models.ComicBook) []models.FieldChange {
+	var changes []models.FieldChange
+
+	add := func(field, ov, nv string) {
+		if ov != nv {
+			changes = append(changes, models.FieldChange{Field: field, Old: ov, New: nv})
+		}
+	}
+
+	keep := func(field, ov, nv string) {
+		if nv != "" {
+			add(field, ov, nv)
+		}
+	}
+
+	keep("description", o.Description, n.Description)
+	keep("source", o.Source, n.Source)
+	keep("url", o.URL, n.URL)
+	add("creators", formatCreators(o.Creators), formatCreators(n.Creators))
+
+	return changes
+}
+
+func formatDate(cb models.ComicBook) string {
+	if cb.ReleaseDate.IsZero() {
+		return ""
+	}
+
+	return cb.ReleaseDate.Format("2006-01-02")
+}
+
+func formatCreators(creators []models.Creator) string {
+	s := make([]string, 0, len(creators))
+	for _, c := range creators {
+		s = append(s, c.Role+": "+c.Name)
+	}
+
+	slices.Sort(s)
+	return strings.Join(s, ", ")
+}
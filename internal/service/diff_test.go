@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"iter"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDiffComicBooks(t *testing.T) {
+	march := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	batman := models.ComicBook{Title: "Batman", Issue: "1", Price: "$4.99", Publisher: "dc", ReleaseDate: march,
+		Description: "Gotham needs him."}
+	superman := models.ComicBook{Title: "Superman", Issue: "2", Price: "$3.99", Publisher: "dc", ReleaseDate: march}
+
+	repriced := batman
+	repriced.Price = "$5.99"
+
+	redescribed := batman
+	redescribed.Description = "Gotham needs him more than ever."
+
+	undescribed := batman
+	undescribed.Description = ""
+
+	resourced := batman
+	resourced.Source = "dc"
+
+	moved := batman
+	moved.ReleaseDate = march.AddDate(0, 0, 7)
+
+	tests := []struct {
+		name    string
+		stored  []models.ComicBook
+		scraped []models.ComicBook
+		want    *models.SyncDiff
+	}{
+		{
+			name:    "nothing stored",
+			stored:  nil,
+			scraped: []models.ComicBook{batman},
+			want:    &models.SyncDiff{New: []models.ComicBook{batman}},
+		},
+		{
+			name:    "unchanged",
+			stored:  []models.ComicBook{batman},
+			scraped: []models.ComicBook{batman},
+			want:    &models.SyncDiff{},
+		},
+		{
+			name:    "changed field",
+			stored:  []models.ComicBook{batman},
+			scraped: []models.ComicBook{redescribed},
+			want: &models.SyncDiff{Changed: []models.ComicBookChange{
+				{ComicBook: redescribed, Fields: []models.FieldChange{
+					{Field: "description", Old: batman.Description, New: redescribed.Description},
+				}},
+			}},
+		},
+		{
+			name:    "missing field is kept",
+			stored:  []models.ComicBook{batman},
+			scraped: []models.ComicBook{undescribed},
+			want:    &models.SyncDiff{},
+		},
+		{
+			name:    "price is not updated",
+			stored:  []models.ComicBook{batman},
+			scraped: []models.ComicBook{repriced},
+			want:    &models.SyncDiff{},
+		},
+		{
+			name:    "other source",
+			stored:  []models.ComicBook{batman},
+			scraped: []models.ComicBook{resourced},
+			want: &models.SyncDiff{Changed: []models.ComicBookChange{
+				{ComicBook: resourced, Fields: []models.FieldChange{{Field: "source", Old: "", New: "dc"}}},
+			}},
+		},
+		{
+			name:    "moved release date is a new book",
+			stored:  []models.ComicBook{batman},
+			scraped: []models.ComicBook{moved},
+			want:    &models.SyncDiff{New: []models.ComicBook{moved}, Removed: []models.ComicBook{batman}},
+		},
+		{
+			name:    "no longer present",
+			stored:  []models.ComicBook{batman, superman},
+			scraped: []models.ComicBook{batman},
+			want:    &models.SyncDiff{Removed: []models.ComicBook{superman}},
+		},
+		{
+			name:    "ignores duplicates",
+			stored:  nil,
+			scraped: []models.ComicBook{batman, batman},
+			want:    &models.SyncDiff{New: []models.ComicBook{batman}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffComicBooks(tt.stored, tt.scraped); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffComicBooks() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// previewProvider is a source whose preview finds the given books.
+type previewProvider struct {
+	fakeProvider
+	books []models.ComicBook
+}
+
+func (p previewProvider) Preview(_ context.Context, _ string, results chan<- models.ComicBook, _ ScrapingObserver) error {
+	for _, cb := range p.books {
+		results <- cb
+	}
+
+	close(results)
+	return nil
+}
+
+// fakeBooks is a book repository that returns the stored books of the queried publishers and months.
+type fakeBooks struct {
+	books []models.ComicBook
+	saved []models.ComicBook
+}
+
+func (f *fakeBooks) BulkSave(_ context.Context, cbs []models.ComicBook) error {
+	f.saved = append(f.saved, cbs...)
+	return nil
+}
+
+func (f *fakeBooks) GetAll(context.Context) ([]models.ComicBook, error) {
+	return f.books, nil
+}
+
+func (f *fakeBooks) Books(_ context.Context, q models.BookQuery) iter.Seq2[models.ComicBook, error] {
+	return func(yield func(models.ComicBook, error) bool) {
+		for _, cb := range f.books {
+			if !slices.Contains(q.Publishers, cb.Publisher) || !slices.Contains(q.Months, cb.ReleaseDate.Month()) {
+				continue
+			}
+
+			if !yield(cb, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (f *fakeBooks) Prune(context.Context, time.Time, bool) (models.PruneSummary, error) {
+	return models.PruneSummary{}, nil
+}
+
+type fakePeople struct {
+	people []models.Person
+}
+
+func (f fakePeople) ListPeople(context.Context, string) ([]models.Person, error) {
+	return f.people, nil
+}
+
+func (f fakePeople) AddAlias(context.Context, string, string) error {
+	return nil
+}
+
+func (f fakePeople) MergePeople(context.Context, string, string) error {
+	return nil
+}
+
+func (f fakePeople) SavePerson(context.Context, models.Person) error {
+	return nil
+}
+
+func (f fakePeople) LinkPeople(context.Context) (int, error) {
+	return 0, nil
+}
+
+func TestSolicitationService_DryRun(t *testing.T) {
+	march := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	stored := models.ComicBook{Title: "Batman", Issue: "1", Publisher: "dc", ReleaseDate: march, Source: "comicreleases",
+		Creators: []models.Creator{{Name: "Tom King", Role: models.RoleWriter}}}
+	april := models.ComicBook{Title: "Superman", Issue: "1", Publisher: "dc", ReleaseDate: march.AddDate(0, 1, 0),
+		Source: "comicreleases"}
+	scraped := models.ComicBook{Title: "Batman", Issue: "1", Publisher: "dc", ReleaseDate: march,
+		Creators: []models.Creator{{Name: "TOM KING", Role: models.RoleWriter}}}
+
+	r, _ := NewProviderRegistry(previewProvider{fakeProvider{"dc"}, []models.ComicBook{scraped}})
+	books := &fakeBooks{books: []models.ComicBook{stored, april}}
+	people := fakePeople{[]models.Person{{Name: "Tom King", Aliases: []string{"tom king"}}}}
+	s := NewSolicitationService(r, books, people, nil)
+
+	got, err := s.DryRun(context.Background(), nil, "dc", []string{"march"}, []string{"dc"})
+	if err != nil {
+		t.Fatalf("DryRun() error = %v", err)
+	}
+
+	want := scraped
+	want.Source = "dc"
+	want.Creators = stored.Creators
+	wantDiff := &models.SyncDiff{Changed: []models.ComicBookChange{
+		{ComicBook: want, Fields: []models.FieldChange{{Field: "source", Old: "comicreleases", New: "dc"}}},
+	}}
+	if !reflect.DeepEqual(got, wantDiff) {
+		t.Errorf("DryRun() = %+v, want %+v", got, wantDiff)
+	}
+
+	if len(books.saved) > 0 {
+		t.Errorf("DryRun() saved %v", books.saved)
+	}
+}
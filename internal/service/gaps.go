@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"slices"
+	"time"
+)
+
+// SeriesGaps lists the holes in the collection of a series. Missing are the released issues that are not owned,
+// Upcoming the unreleased issues that are not on the pull list yet.
+type SeriesGaps struct {
+	Series   models.Series
+	Missing  []models.SeriesIssue
+	Upcoming []models.SeriesIssue
+}
+
+// Gaps finds the gaps in every series with a book in the collection, limited to publisher when it is set. Issues
+// are compared by number, so owning any printing of an issue counts, and books without a number are skipped. Only
+// singles are compared: a trade does not stand in for the issue with its volume number. Books released before now
+// count as released, books without a release date as upcoming.
+func (c *CollectionService) Gaps(ctx context.Context, publisher string, now time.Time) ([]SeriesGaps, error) {
+	series, err := c.series.CollectedSeries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var gaps []SeriesGaps
+	for _, s := range series {
+		if (publisher != "" && s.Publisher != publisher) || s.Kind == models.SeriesCollected {
+			continue
+		}
+
+		issues, err := c.series.SeriesIssues(ctx, s.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		g := findGaps(s, issues, now)
+		if len(g.Missing) > 0 || len(g.Upcoming) > 0 {
+			gaps = append(gaps, g)
+		}
+	}
+
+	return gaps, nil
+}
+
+// findGaps expects issues ordered by number, as returned by the repository.
+func findGaps(s models.Series, issues []models.SeriesIssue, now time.Time) SeriesGaps {
+	g := SeriesGaps{Series: s}
+
+	issues = slices.DeleteFunc(slices.Clone(issues), func(is models.SeriesIssue) bool {
+		sr, _ := models.SeriesOf(is.ComicBook)
+		return sr.Kind == models.SeriesCollected
+	})
+
+	for group := range groupByNumber(issues) {
+		first := group[0]
+		for _, is := range group[1:] {
+			if is.ReleaseDate.IsZero() {
+				continue
+			}
+
+			if first.ReleaseDate.IsZero() || is.ReleaseDate.Before(first.ReleaseDate) {
+				first = is
+			}
+		}
+
+		owned := slices.ContainsFunc(group, func(is models.SeriesIssue) bool {
+			return is.Status == models.StatusOwned || is.Status == models.StatusRead
+		})
+		tracked := slices.ContainsFunc(group, func(is models.SeriesIssue) bool {
+			return is.Status != ""
+		})
+
+		switch {
+		case owned:
+		case !first.ReleaseDate.IsZero() && first.ReleaseDate.Before(now):
+			g.Missing = append(g.Missing, first)
+		case !tracked:
+			g.Upcoming = append(g.Upcoming, first)
+		}
+	}
+
+	return g
+}
+
+// groupByNumber yields the numbered issues grouped by their issue number.
+func groupByNumber(issues []models.SeriesIssue) func(yield func([]models.SeriesIssue) bool) {
+	return func(yield func([]models.SeriesIssue) bool) {
+		var group []models.SeriesIssue
+
+		for _, is := range issues {
+			if !is.Number.Numeric {
+				continue
+			}
+
+			if len(group) > 0 && group[0].Number.Number != is.Number.Number {
+				if !yield(group) {
+					return
+				}
+				group = nil
+			}
+
+			group = append(group, is)
+		}
+
+		if len(group) > 0 {
+			yield(group)
+		}
+	}
+}
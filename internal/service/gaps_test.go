@@ -0,0 +1,111 @@
+package service
+
+import (
+	"github.com/MikkelvtK/solipull/internal/models"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func seriesIssue(issue string, release time.Time, status string) models.SeriesIssue {
+	return models.SeriesIssue{
+		ComicBook: models.ComicBook{Title: "Batman", Issue: issue, ReleaseDate: release},
+		Number:    models.ParseIssue(issue),
+		Status:    status,
+	}
+}
+
+func Test_findGaps(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	released := now.AddDate(0, -1, 0)
+	upcoming := now.AddDate(0, 0, 14)
+
+	s := models.Series{Title: "Batman", Publisher: "dc", Kind: models.SeriesOngoing}
+
+	tests := []struct {
+		name         string
+		issues       []models.SeriesIssue
+		wantMissing  []string
+		wantUpcoming []string
+	}{
+		{
+			name: "released issues that are not owned",
+			issues: []models.SeriesIssue{
+				seriesIssue("1", released, models.StatusOwned),
+				seriesIssue("2", released, ""),
+				seriesIssue("3", released, models.StatusOrdered),
+				seriesIssue("4", released, models.StatusRead),
+			},
+			wantMissing: []string{"2", "3"},
+		},
+		{
+			name: "any printing counts as owned",
+			issues: []models.SeriesIssue{
+				seriesIssue("1", released, ""),
+				seriesIssue("1 Facsimile Edition", released, models.StatusOwned),
+			},
+		},
+		{
+			name: "upcoming issues that are not on the pull list",
+			issues: []models.SeriesIssue{
+				seriesIssue("5", upcoming, models.StatusWanted),
+				seriesIssue("6", upcoming, ""),
+			},
+			wantUpcoming: []string{"6"},
+		},
+		{
+			name: "issues without a release date are upcoming",
+			issues: []models.SeriesIssue{
+				seriesIssue("7", time.Time{}, ""),
+				seriesIssue("8", time.Time{}, ""),
+				seriesIssue("8 Facsimile Edition", released, ""),
+			},
+			wantMissing:  []string{"8 Facsimile Edition"},
+			wantUpcoming: []string{"7"},
+		},
+		{
+			name: "a trade does not count as the single with its number",
+			issues: []models.SeriesIssue{
+				seriesIssue("3", released, ""),
+				{
+					ComicBook: models.ComicBook{Title: "Batman Vol. 3 HC", Format: "hardcovers", ReleaseDate: released},
+					Number:    models.ParseIssue("3"),
+					Status:    models.StatusOwned,
+				},
+				{
+					ComicBook: models.ComicBook{Title: "Batman Vol. 4 TP", Format: "trades", ReleaseDate: released},
+					Number:    models.ParseIssue("4"),
+				},
+			},
+			wantMissing: []string{"3"},
+		},
+		{
+			name: "skips books without a number",
+			issues: []models.SeriesIssue{
+				seriesIssue("1", released, models.StatusOwned),
+				seriesIssue("Variant", released, ""),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findGaps(s, tt.issues, now)
+
+			if issues := issueStrings(got.Missing); !reflect.DeepEqual(issues, tt.wantMissing) {
+				t.Errorf("findGaps() missing = %v, want %v", issues, tt.wantMissing)
+			}
+			if issues := issueStrings(got.Upcoming); !reflect.DeepEqual(issues, tt.wantUpcoming) {
+				t.Errorf("findGaps() upcoming = %v, want %v", issues, tt.wantUpcoming)
+			}
+		})
+	}
+}
+
+func issueStrings(issues []models.SeriesIssue) []string {
+	var s []string
+	for _, is := range issues {
+		s = append(s, is.Issue)
+	}
+
+	return s
+}
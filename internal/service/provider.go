@@ -0,0 +1,61 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ProviderRegistry holds the data providers solicitations can be synced from, keyed by their name.
+type ProviderRegistry struct {
+	providers map[string]DataProvider
+	names     []string
+}
+
+func NewProviderRegistry(providers ...DataProvider) (*ProviderRegistry, error) {
+	r := &ProviderRegistry{providers: make(map[string]DataProvider)}
+
+	for _, p := range providers {
+		if err := r.Register(p); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// Register adds a provider to the registry. The first registered provider is used when no source is given.
+func (r *ProviderRegistry) Register(p DataProvider) error {
+	if p == nil {
+		return fmt.Errorf("provider is nil")
+	}
+
+	if _, ok := r.providers[p.Name()]; ok {
+		return fmt.Errorf("provider already registered: %s", p.Name())
+	}
+
+	r.providers[p.Name()] = p
+	r.names = append(r.names, p.Name())
+	return nil
+}
+
+// Get returns the provider registered under name, or the default provider if name is empty.
+func (r *ProviderRegistry) Get(name string) (DataProvider, error) {
+	if name == "" {
+		if len(r.names) == 0 {
+			return nil, fmt.Errorf("no providers registered")
+		}
+
+		name = r.names[0]
+	}
+
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown source: %s", name)
+	}
+
+	return p, nil
+}
+
+func (r *ProviderRegistry) Names() []string {
+	return slices.Clone(r.names)
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"reflect"
+	"testing"
+)
+
+type fakeProvider struct {
+	name string
+}
+
+func (f fakeProvider) Name() string {
+	return f.name
+}
+
+func (f fakeProvider) StartURL() string {
+	return "https://" + f.name + ".com"
+}
+
+func (f fakeProvider) Publishers() []string {
+	return []string{f.name}
+}
+
+func (f fakeProvider) GetData(_ context.Context, _ string, results chan<- models.ComicBook, _ ScrapingObserver) error {
+	close(results)
+	return nil
+}
+
+func (f fakeProvider) Preview(_ context.Context, _ string, results chan<- models.ComicBook, _ ScrapingObserver) error {
+	close(results)
+	return nil
+}
+
+func (f fakeProvider) Resume(_ context.Context, results chan<- models.ComicBook, _ ScrapingObserver) error {
+	close(results)
+	return nil
+}
+
+func (f fakeProvider) GetPages(_ context.Context, _ []string, _ string, results chan<- models.ComicBook, _ ScrapingObserver) error {
+	close(results)
+	return nil
+}
+
+func (f fakeProvider) SetInputs(_, _ []string) error {
+	return nil
+}
+
+func TestNewProviderRegistry(t *testing.T) {
+	tests := []struct {
+		name      string
+		providers []DataProvider
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name:      "empty registry",
+			providers: nil,
+			wantNames: nil,
+		},
+		{
+			name:      "keeps registration order",
+			providers: []DataProvider{fakeProvider{"b"}, fakeProvider{"a"}},
+			wantNames: []string{"b", "a"},
+		},
+		{
+			name:      "duplicate names",
+			providers: []DataProvider{fakeProvider{"a"}, fakeProvider{"a"}},
+			wantErr:   true,
+		},
+		{
+			name:      "nil provider",
+			providers: []DataProvider{nil},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewProviderRegistry(tt.providers...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewProviderRegistry() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.Names(), tt.wantNames) {
+				t.Errorf("Names() = %v, want %v", got.Names(), tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestProviderRegistry_Get(t *testing.T) {
+	r, _ := NewProviderRegistry(fakeProvider{"a"}, fakeProvider{"b"})
+	empty, _ := NewProviderRegistry()
+
+	tests := []struct {
+		name     string
+		registry *ProviderRegistry
+		source   string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "returns named provider",
+			registry: r,
+			source:   "b",
+			want:     "b",
+		},
+		{
+			name:     "defaults to first provider",
+			registry: r,
+			source:   "",
+			want:     "a",
+		},
+		{
+			name:     "unknown source",
+			registry: r,
+			source:   "c",
+			wantErr:  true,
+		},
+		{
+			name:     "no providers",
+			registry: empty,
+			source:   "",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.registry.Get(tt.source)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Name() != tt.want {
+				t.Errorf("Get() = %v, want %v", got.Name(), tt.want)
+			}
+		})
+	}
+}
+
+func TestSolicitationService_Publishers(t *testing.T) {
+	r, _ := NewProviderRegistry(fakeProvider{"dc"}, fakeProvider{"boom"})
+	s := NewSolicitationService(r, nil, nil, nil)
+
+	if got, want := s.Publishers(), []string{"dc", "boom"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Publishers() = %v, want %v", got, want)
+	}
+}
+
+func TestSolicitationService_provider(t *testing.T) {
+	r, _ := NewProviderRegistry(fakeProvider{"dc"}, fakeProvider{"boom"})
+	s := NewSolicitationService(r, nil, nil, nil)
+
+	tests := []struct {
+		name       string
+		source     string
+		publishers []string
+		want       string
+		wantErr    bool
+	}{
+		{name: "named source", source: "dc", publishers: []string{"boom"}, want: "dc"},
+		{name: "default source", want: "dc"},
+		{name: "source of the publisher", publishers: []string{"boom"}, want: "boom"},
+		{name: "no source of every publisher", publishers: []string{"dc", "boom"}, wantErr: true},
+		{name: "no source of the publisher", publishers: []string{"dynamite"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.provider(tt.source, tt.publishers)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("provider() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Name() != tt.want {
+				t.Errorf("provider() = %v, want %v", got.Name(), tt.want)
+			}
+		})
+	}
+}
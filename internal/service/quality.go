@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"log/slog"
+	"sync"
+)
+
+// qualityTracker collects the extraction quality of every page a sync visits. It sits between the provider and the
+// observer of the sync, counting the pages as they are scraped and the warnings about the fields of their books.
+type qualityTracker struct {
+	ScrapingObserver
+
+	mu    sync.Mutex
+	pages map[string]*models.PageQuality
+	urls  []string
+}
+
+func newQualityTracker(obs ScrapingObserver) *qualityTracker {
+	return &qualityTracker{ScrapingObserver: obs, pages: make(map[string]*models.PageQuality)}
+}
+
+func (q *qualityTracker) OnError(ctx context.Context, level slog.Level, msg string, args ...any) {
+	if q.ScrapingObserver != nil {
+		q.ScrapingObserver.OnError(ctx, level, msg, args...)
+	}
+
+	url, ok := pageOf(ctx)
+	if !ok {
+		return
+	}
+
+	for i := 0; i+1 < len(args); i += 2 {
+		if args[i] == models.FieldKey {
+			field, _ := args[i+1].(string)
+
+			q.mu.Lock()
+			q.page(url).Missing(field)
+			q.mu.Unlock()
+			return
+		}
+	}
+}
+
+func (q *qualityTracker) OnPageStart(url string) {
+	if po, ok := q.ScrapingObserver.(PageObserver); ok {
+		po.OnPageStart(url)
+	}
+}
+
+func (q *qualityTracker) OnPageScraped(url string, books int) {
+	q.mu.Lock()
+	q.page(url).Books = books
+	q.mu.Unlock()
+
+	if po, ok := q.ScrapingObserver.(PageObserver); ok {
+		po.OnPageScraped(url, books)
+	}
+}
+
+func (q *qualityTracker) OnPageFailed(url string, err error) {
+	q.mu.Lock()
+	q.page(url)
+	q.mu.Unlock()
+
+	if po, ok := q.ScrapingObserver.(PageObserver); ok {
+		po.OnPageFailed(url, err)
+	}
+}
+
+// page returns the quality of the page at url, adding it when it was not visited before. The caller holds mu.
+func (q *qualityTracker) page(url string) *models.PageQuality {
+	p, ok := q.pages[url]
+	if !ok {
+		p = &models.PageQuality{URL: url}
+		q.pages[url] = p
+		q.urls = append(q.urls, url)
+	}
+
+	return p
+}
+
+func (q *qualityTracker) results() []models.PageQuality {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	res := make([]models.PageQuality, 0, len(q.urls))
+	for _, url := range q.urls {
+		res = append(res, *q.pages[url])
+	}
+
+	return res
+}
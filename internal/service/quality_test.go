@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/MikkelvtK/solipull/internal/models"
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+type nopObserver struct{}
+
+func (nopObserver) OnError(context.Context, slog.Level, string, ...any) {}
+func (nopObserver) OnStart()                                            {}
+func (nopObserver) OnUrlFound(int)                                      {}
+func (nopObserver) OnNavigationComplete()                               {}
+func (nopObserver) OnComicBookScraped(int)                              {}
+func (nopObserver) OnScrapingComplete()                                 {}
+
+// pageProvider is a source that reports the visited pages and the warnings about their books to the observer.
+type pageProvider struct {
+	fakeProvider
+	scrape func(ctx context.Context, obs ScrapingObserver)
+}
+
+func (p pageProvider) GetData(ctx context.Context, _ string, results chan<- models.ComicBook, obs ScrapingObserver) error {
+	p.scrape(ctx, obs)
+
+	close(results)
+	return nil
+}
+
+type fakeRuns struct {
+	saved []models.SyncRun
+}
+
+func (f *fakeRuns) SaveRun(_ context.Context, run models.SyncRun) error {
+	f.saved = append(f.saved, run)
+	return nil
+}
+
+func (f *fakeRuns) LastRun(context.Context, string) (models.SyncRun, error) {
+	return models.SyncRun{}, errors.New("not implemented")
+}
+
+func TestSolicitationService_Sync_Quality(t *testing.T) {
+	tests := []struct {
+		name   string
+		scrape func(ctx context.Context, obs ScrapingObserver)
+		want   []models.PageQuality
+	}{
+		{
+			name: "every visited page",
+			scrape: func(ctx context.Context, obs ScrapingObserver) {
+				po := obs.(PageObserver)
+
+				a := WithPage(ctx, "a")
+				obs.OnError(a, slog.LevelWarn, "title not found", models.FieldKey, models.FieldTitle)
+				obs.OnError(a, slog.LevelWarn, "no price found", "string", "", models.FieldKey, models.FieldPrice)
+				obs.OnError(a, slog.LevelWarn, "request failed")
+				obs.OnError(ctx, slog.LevelWarn, "no price found", models.FieldKey, models.FieldPrice)
+				po.OnPageScraped("a", 2)
+
+				po.OnPageScraped("b", 0)
+				po.OnPageFailed("c", errors.New("not found"))
+			},
+			want: []models.PageQuality{
+				{URL: "a", Books: 2, Dropped: 1, MissingPrice: 1},
+				{URL: "b"},
+				{URL: "c"},
+			},
+		},
+		{
+			name:   "no pages",
+			scrape: func(context.Context, ScrapingObserver) {},
+			want:   []models.PageQuality{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewProviderRegistry(pageProvider{fakeProvider: fakeProvider{name: "dc"}, scrape: tt.scrape})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			runs := &fakeRuns{}
+			s := NewSolicitationService(r, &fakeBooks{}, nil, runs)
+
+			if err := s.Sync(context.Background(), nopObserver{}, "dc", []string{"march"}, []string{"dc"}); err != nil {
+				t.Fatalf("Sync() error = %v", err)
+			}
+
+			if len(runs.saved) != 1 {
+				t.Fatalf("Sync() saved %d runs, want 1", len(runs.saved))
+			}
+
+			if got := runs.saved[0].Pages; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Sync() pages = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
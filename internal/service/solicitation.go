@@ -2,16 +2,28 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/MikkelvtK/solipull/internal/models"
+	"iter"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 )
 
-const (
-	Domain = "comicreleases.com"
-)
+var ErrNothingToResume = errors.New("no interrupted sync to resume")
 
 type DataProvider interface {
+	Name() string
+	StartURL() string
+	// Publishers returns the publishers the provider has solicitations of.
+	Publishers() []string
 	GetData(ctx context.Context, url string, results chan<- models.ComicBook, observer ScrapingObserver) error
+	// Preview scrapes like GetData without touching the resume state of an interrupted sync.
+	Preview(ctx context.Context, url string, results chan<- models.ComicBook, observer ScrapingObserver) error
+	Resume(ctx context.Context, results chan<- models.ComicBook, observer ScrapingObserver) error
+	GetPages(ctx context.Context, urls []string, publisher string, results chan<- models.ComicBook, observer ScrapingObserver) error
 	SetInputs(months, publishers []string) error
 }
 
@@ -24,34 +36,239 @@ type ScrapingObserver interface {
 	OnScrapingComplete()
 }
 
+// PageObserver can be implemented next to ScrapingObserver to follow every solicitation page of a sync. Providers
+// report to it when the observer they are given implements it.
+type PageObserver interface {
+	OnPageStart(url string)
+	OnPageScraped(url string, books int)
+	OnPageFailed(url string, err error)
+}
+
+type pageKey struct{}
+
+// WithPage returns a context for extracting a book from the page at url. The warnings reported with it count towards
+// the extraction quality of that page.
+func WithPage(ctx context.Context, url string) context.Context {
+	return context.WithValue(ctx, pageKey{}, url)
+}
+
+func pageOf(ctx context.Context) (string, bool) {
+	url, ok := ctx.Value(pageKey{}).(string)
+	return url, ok
+}
+
 type SolicitationService struct {
-	scraper DataProvider
-	repo    models.ComicBookRepository
+	providers *ProviderRegistry
+	repo      models.ComicBookRepository
+	people    models.PersonRepository
+	runs      models.SyncRunRepository
 }
 
-func NewSolicitationService(p DataProvider, r models.ComicBookRepository) *SolicitationService {
+func NewSolicitationService(p *ProviderRegistry, r models.ComicBookRepository, people models.PersonRepository,
+	runs models.SyncRunRepository) *SolicitationService {
 	return &SolicitationService{
-		scraper: p,
-		repo:    r,
+		providers: p,
+		repo:      r,
+		people:    people,
+		runs:      runs,
+	}
+}
+
+// Sources returns the names of the providers that can be synced from.
+func (s *SolicitationService) Sources() []string {
+	return s.providers.Names()
+}
+
+// Publishers returns the publishers the providers have solicitations of, in the order they are registered.
+func (s *SolicitationService) Publishers() []string {
+	var publishers []string
+	for _, name := range s.providers.Names() {
+		p, _ := s.providers.Get(name)
+		for _, pub := range p.Publishers() {
+			if !slices.Contains(publishers, pub) {
+				publishers = append(publishers, pub)
+			}
+		}
+	}
+
+	return publishers
+}
+
+// provider returns the provider registered as source. Without a source, it is the first provider with solicitations
+// of all the publishers, so a publisher that only a configured source provides is not looked for on Comic Releases.
+func (s *SolicitationService) provider(source string, publishers []string) (DataProvider, error) {
+	if source != "" || len(publishers) == 0 {
+		return s.providers.Get(source)
+	}
+
+	for _, name := range s.providers.Names() {
+		p, _ := s.providers.Get(name)
+		if !slices.ContainsFunc(publishers, func(pub string) bool { return !slices.Contains(p.Publishers(), pub) }) {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no source provides the solicitations of %s", strings.Join(publishers, ", "))
+}
+
+func (s *SolicitationService) Sync(ctx context.Context, observer ScrapingObserver, source string, months, publishers []string) error {
+	p, err := s.provider(source, publishers)
+	if err != nil {
+		return err
+	}
+
+	if err := p.SetInputs(months, publishers); err != nil {
+		return err
+	}
+
+	return s.recordRun(ctx, p.Name(), observer, func(obs ScrapingObserver) error {
+		return s.collect(ctx, func(results chan<- models.ComicBook) error {
+			return p.GetData(ctx, p.StartURL(), results, obs)
+		}, s.saver(ctx, p.Name()))
+	})
+}
+
+// Resume continues a sync that was interrupted, scraping only the pages that were not completed yet.
+func (s *SolicitationService) Resume(ctx context.Context, observer ScrapingObserver, source string) error {
+	p, err := s.providers.Get(source)
+	if err != nil {
+		return err
+	}
+
+	return s.recordRun(ctx, p.Name(), observer, func(obs ScrapingObserver) error {
+		return s.collect(ctx, func(results chan<- models.ComicBook) error {
+			return p.Resume(ctx, results, obs)
+		}, s.saver(ctx, p.Name()))
+	})
+}
+
+// LastRun returns the most recent sync of source, or of any source when source is empty.
+func (s *SolicitationService) LastRun(ctx context.Context, source string) (models.SyncRun, error) {
+	return s.runs.LastRun(ctx, source)
+}
+
+// SyncPages scrapes the given solicitation pages directly, without looking for them through the start URL of the
+// source. The publisher is derived from the URL unless one is given. The books are only stored when save is true.
+func (s *SolicitationService) SyncPages(ctx context.Context, observer ScrapingObserver, source string, urls []string, publisher string, save bool) ([]models.ComicBook, error) {
+	var publishers []string
+	if publisher != "" {
+		publishers = []string{publisher}
+	}
+
+	p, err := s.provider(source, publishers)
+	if err != nil {
+		return nil, err
+	}
+
+	var cbs []models.ComicBook
+
+	scrape := func(obs ScrapingObserver) error {
+		return s.collect(ctx, func(results chan<- models.ComicBook) error {
+			return p.GetPages(ctx, urls, publisher, results, obs)
+		}, func(res <-chan models.ComicBook) error {
+			for cb := range res {
+				cb.Source = p.Name()
+				cbs = append(cbs, cb)
+			}
+			return nil
+		})
+	}
+
+	if !save {
+		if err := scrape(observer); err != nil {
+			return nil, err
+		}
+
+		return cbs, nil
+	}
+
+	err = s.recordRun(ctx, p.Name(), observer, func(obs ScrapingObserver) error {
+		if err := scrape(obs); err != nil || len(cbs) == 0 {
+			return err
+		}
+
+		return s.repo.BulkSave(ctx, cbs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cbs, nil
+}
+
+// DryRun scrapes like Sync but, instead of saving the results, compares them to the stored books of the same
+// publishers and months, whatever source stored them. Nothing is written, not even the queue an interrupted sync
+// resumes from.
+func (s *SolicitationService) DryRun(ctx context.Context, observer ScrapingObserver, source string, months, publishers []string) (*models.SyncDiff, error) {
+	p, err := s.provider(source, publishers)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.SetInputs(months, publishers); err != nil {
+		return nil, err
+	}
+
+	var scraped []models.ComicBook
+
+	err = s.collect(ctx, func(results chan<- models.ComicBook) error {
+		return p.Preview(ctx, p.StartURL(), results, observer)
+	}, func(res <-chan models.ComicBook) error {
+		for cb := range res {
+			cb.Source = p.Name()
+			scraped = append(scraped, cb)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	people, err := s.people.ListPeople(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	q := models.BookQuery{Publishers: publishers}
+	for m := time.January; m <= time.December; m++ {
+		if slices.ContainsFunc(months, func(name string) bool { return strings.EqualFold(name, m.String()) }) {
+			q.Months = append(q.Months, m)
+		}
 	}
+
+	var stored []models.ComicBook
+	for cb, err := range s.repo.Books(ctx, q) {
+		if err != nil {
+			return nil, err
+		}
+
+		stored = append(stored, cb)
+	}
+
+	return diffComicBooks(stored, resolveCreators(scraped, people)), nil
 }
 
-func (s *SolicitationService) Sync(ctx context.Context, observer ScrapingObserver, months, publishers []string) error {
+func (s *SolicitationService) collect(ctx context.Context, scrape func(results chan<- models.ComicBook) error, consume func(res <-chan models.ComicBook) error) error {
 	results := make(chan models.ComicBook, 100)
 	errCh := make(chan error, 1)
 	wg := &sync.WaitGroup{}
-	url := "https://" + Domain + "/sitemap.xml"
 
 	defer close(errCh)
 
-	if err := s.scraper.SetInputs(months, publishers); err != nil {
-		return err
-	}
-
 	wg.Add(1)
-	go s.bulkSave(ctx, results, errCh, wg)
+	go func() {
+		defer wg.Done()
+
+		if err := consume(results); err != nil {
+			errCh <- err
+		}
+
+		// Keep draining, so the provider does not block on a consumer that gave up.
+		for range results {
+		}
+	}()
 
-	err := s.scraper.GetData(ctx, url, results, observer)
+	err := scrape(results)
 	wg.Wait()
 
 	if err != nil {
@@ -66,23 +283,47 @@ func (s *SolicitationService) Sync(ctx context.Context, observer ScrapingObserve
 	}
 }
 
-func (s *SolicitationService) View(ctx context.Context, months, publishers []string) ([]models.ComicBook, error) {
-	// TODO: implement to get comic books by months and publishers
-	return s.repo.GetAll(ctx)
+// saver returns a consumer that stores the scraped books in batches. Books that were already scraped are still
+// saved when the sync gets cancelled.
+func (s *SolicitationService) saver(ctx context.Context, source string) func(res <-chan models.ComicBook) error {
+	return func(res <-chan models.ComicBook) error {
+		return s.bulkSave(context.WithoutCancel(ctx), source, res)
+	}
 }
 
-func (s *SolicitationService) bulkSave(ctx context.Context, res <-chan models.ComicBook, errCh chan<- error, wg *sync.WaitGroup) {
-	defer wg.Done()
+// recordRun runs a sync with an observer that tracks the extraction quality of every page it visits, and stores the
+// run also when the sync fails halfway through or finds no pages at all.
+func (s *SolicitationService) recordRun(ctx context.Context, source string, observer ScrapingObserver, sync func(obs ScrapingObserver) error) error {
+	q := newQualityTracker(observer)
+	run := models.SyncRun{Source: source, StartedAt: time.Now()}
+
+	err := sync(q)
 
+	run.FinishedAt = time.Now()
+	run.Pages = q.results()
+
+	if s.runs == nil {
+		return err
+	}
+
+	return errors.Join(err, s.runs.SaveRun(context.WithoutCancel(ctx), run))
+}
+
+// View streams the stored books matching q, in the order of q.Sort.
+func (s *SolicitationService) View(ctx context.Context, q models.BookQuery) iter.Seq2[models.ComicBook, error] {
+	return s.repo.Books(ctx, q)
+}
+
+func (s *SolicitationService) bulkSave(ctx context.Context, source string, res <-chan models.ComicBook) error {
 	cbs := make([]models.ComicBook, 0, 100)
 
 	for cb := range res {
+		cb.Source = source
 		cbs = append(cbs, cb)
 
 		if len(cbs) >= 100 {
 			if err := s.repo.BulkSave(ctx, cbs); err != nil {
-				errCh <- err
-				return
+				return err
 			}
 
 			cbs = cbs[:0]
@@ -90,8 +331,8 @@ func (s *SolicitationService) bulkSave(ctx context.Context, res <-chan models.Co
 	}
 
 	if len(cbs) > 0 {
-		if err := s.repo.BulkSave(ctx, cbs); err != nil {
-			errCh <- err
-		}
+		return s.repo.BulkSave(ctx, cbs)
 	}
+
+	return nil
 }